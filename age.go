@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// imageAgeBuckets are the age filters of the Images view, cycled in
+// order; 0 shows images of any age.
+var imageAgeBuckets = []time.Duration{0, 7 * 24 * time.Hour, 30 * 24 * time.Hour, 90 * 24 * time.Hour}
+
+// ageLabel renders an age bucket in days, e.g. "7d".
+func ageLabel(age time.Duration) string {
+	return fmt.Sprintf("%dd", int(age.Hours()/24))
+}
+
+// cycleImageAge steps the Images view through showing only images older
+// than 7, 30 and 90 days, then all images again. Together with Delete All
+// this cleans up by age in two keystrokes.
+func (app *App) cycleImageAge() {
+	app.ageBucket = (app.ageBucket + 1) % len(imageAgeBuckets)
+	app.pageStart = 0
+	app.filterItems()
+
+	if age := app.imageAgeFilter(); age > 0 {
+		app.updateStatus(fmt.Sprintf("Showing [green]%d[white] images older than %s (E: next)", len(app.itemCache), ageLabel(age)))
+	} else {
+		app.updateStatus("Showing images of any age")
+	}
+}
+
+// imageAgeFilter returns the minimum age of the images the Images view
+// shows, or 0 for no age filter.
+func (app *App) imageAgeFilter() time.Duration {
+	if app.currentResource != ResourceImages {
+		return 0
+	}
+	return imageAgeBuckets[app.ageBucket]
+}
+
+// filterImageAge keeps the images older than age. Images without a
+// creation time are left out, since their age is unknown.
+func filterImageAge(items []interface{}, age time.Duration) []interface{} {
+	cutoff := time.Now().Add(-age)
+	var old []interface{}
+	for _, item := range items {
+		if created := item.(ImageInfo).CreatedAt; !created.IsZero() && created.Before(cutoff) {
+			old = append(old, item)
+		}
+	}
+	return old
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// confirmDeleteAliases offers to delete the selected image together with
+// every other tag pointing at the same content, so the content is actually
+// freed.
+func (app *App) confirmDeleteAliases() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("delete the tags of")
+		return
+	}
+	img, ok := item.(ImageInfo)
+	if !ok {
+		return
+	}
+
+	names := append([]string{img.Name}, sameContentTags(app.allItems, img)...)
+	slices.Sort(names)
+	app.confirmDeleteContentGroup(names, img.Size)
+}
+
+// confirmDeleteContentGroup asks before deleting all tags of a group of
+// images sharing the same content.
+func (app *App) confirmDeleteContentGroup(names []string, size int64) {
+	if len(names) == 1 {
+		app.updateStatus(fmt.Sprintf("[yellow]No other tag points at the content of %s[white]; press d to delete it", tview.Escape(names[0])))
+		return
+	}
+
+	shown := names
+	more := ""
+	if len(shown) > 10 {
+		more = fmt.Sprintf("\n… and %d more", len(shown)-10)
+		shown = shown[:10]
+	}
+	escaped := make([]string, len(shown))
+	for i, name := range shown {
+		escaped[i] = tview.Escape(name)
+	}
+
+	stopCountdown := func() {}
+	buttons := []string{"Delete All Tags", "Cancel"}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete all %d tags sharing this content?\n\n%s%s\n\nSize: %s\nOnce the last tag is gone, containerd garbage collects the content, except for layers other images share. This action cannot be undone!",
+			len(names), strings.Join(escaped, "\n"), more, app.sizeText(size))).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-aliases")
+			if buttonLabel == "Delete All Tags" {
+				app.deleteContentGroup(names)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Delete Tags ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-aliases", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, buttons, app.deleteCountdown)
+	}
+}
+
+// deleteContentGroup deletes images sharing the same content. Only the
+// last delete waits for the garbage collection, which then frees the
+// content of all of them.
+func (app *App) deleteContentGroup(names []string) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	imageService := app.client.ImageService()
+	chain := app.imageChainIDs(ctx, names[0])
+
+	var failed []string
+	for i, name := range names {
+		var opts []images.DeleteOpt
+		if i == len(names)-1 {
+			opts = append(opts, images.SynchronousDelete())
+		}
+		err := imageService.Delete(ctx, name, opts...)
+		if err != nil && classifyDeleteError(err) != deleteNotFound {
+			failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		app.recordDeletion(app.currentNamespace, ResourceImages.String(), name)
+	}
+
+	app.contentChanged(app.currentNamespace)
+	app.loadItems()
+	if len(failed) > 0 {
+		app.showError(fmt.Sprintf("Deleted %d of %d tags; the content stays until the rest are gone.\n\n%s",
+			len(names)-len(failed), len(names), tview.Escape(strings.Join(failed, "\n"))))
+		return
+	}
+	app.updateStatus(fmt.Sprintf("[green]Deleted %d tags[white] sharing the content of %s", len(names), tview.Escape(names[0])))
+	app.offerImageSnapshotCleanup(names[0], chain)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	remoteerrors "github.com/containerd/containerd/remotes/errors"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// registryCredentials authenticate against a registry. An empty username
+// with a secret is an identity token, as issued by `docker login`.
+type registryCredentials struct {
+	username string
+	secret   string
+}
+
+// newResolver returns a registry resolver that authenticates with creds,
+// or with the credentials stored in the Docker config when creds is nil.
+func (app *App) newResolver(creds *registryCredentials) remotes.Resolver {
+	authorizer := docker.NewDockerAuthorizer(
+		docker.WithAuthCreds(func(host string) (string, string, error) {
+			if creds != nil {
+				return creds.username, creds.secret, nil
+			}
+			stored := app.storedCredentials(host)
+			if stored == nil {
+				return "", "", nil
+			}
+			return stored.username, stored.secret, nil
+		}))
+
+	return docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(docker.WithAuthorizer(authorizer)),
+	})
+}
+
+// storedCredentials returns the credentials stored in the Docker config
+// for a registry host, or nil. A broken config or credential helper must
+// not fail pulls from registries that need no login, so its error is only
+// reported in the status bar and the request goes on without credentials;
+// a registry that does need them then asks for them.
+func (app *App) storedCredentials(host string) *registryCredentials {
+	stored, err := dockerCredentials(host)
+	if err != nil {
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.updateStatus(fmt.Sprintf("[yellow]Ignoring stored credentials for %s:[white] %v", host, err))
+		})
+		return nil
+	}
+	return stored
+}
+
+// isUnauthorized reports whether a pull or push failed because the registry
+// requires (other) credentials.
+func isUnauthorized(err error) bool {
+	if errors.Is(err, docker.ErrInvalidAuthorization) {
+		return true
+	}
+	var status remoteerrors.ErrUnexpectedStatus
+	if errors.As(err, &status) {
+		return status.StatusCode == http.StatusUnauthorized
+	}
+
+	// Pulls through the transfer service fail on the daemon, and only
+	// the message of the error makes it back
+	message := err.Error()
+	return strings.Contains(message, docker.ErrInvalidAuthorization.Error()) || strings.Contains(message, "401 Unauthorized")
+}
+
+// registryHost returns the registry domain of an image reference.
+func registryHost(ref string) string {
+	named, err := reference.ParseDockerRef(ref)
+	if err != nil {
+		return ref
+	}
+	return reference.Domain(named)
+}
+
+// promptRegistryCredentials asks for the credentials to pull or push ref
+// with, as action says, and calls retry with them. previous are the
+// credentials that were refused, if any, to prefill the username.
+func (app *App) promptRegistryCredentials(action, ref string, previous *registryCredentials, cause error, retry func(*registryCredentials)) {
+	userInput := tview.NewInputField().
+		SetLabel("Username: ").
+		SetFieldWidth(40)
+
+	passInput := tview.NewInputField().
+		SetLabel("Password: ").
+		SetFieldWidth(40).
+		SetMaskCharacter('*')
+
+	if previous != nil {
+		userInput.SetText(previous.username)
+	}
+
+	closeDialog := func() {
+		app.closeDialog("registry-auth")
+	}
+
+	submit := func() {
+		closeDialog()
+
+		secret := passInput.GetText()
+		if secret == "" {
+			return
+		}
+		retry(&registryCredentials{
+			username: strings.TrimSpace(userInput.GetText()),
+			secret:   secret,
+		})
+	}
+
+	for _, input := range []*tview.InputField{userInput, passInput} {
+		input.SetDoneFunc(func(key tcell.Key) {
+			if key == tcell.KeyEnter {
+				submit()
+			} else if key == tcell.KeyEscape {
+				closeDialog()
+				app.showError(fmt.Sprintf("Failed to %s %s: %v", action, ref, cause))
+			}
+		})
+	}
+
+	title := fmt.Sprintf(" Login to %s ", registryHost(ref))
+	if previous != nil {
+		title = fmt.Sprintf(" Login to %s failed, retry ", registryHost(ref))
+	}
+
+	form := tview.NewForm().
+		AddFormItem(userInput).
+		AddFormItem(passInput)
+
+	form.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 60, 1, true).
+			AddItem(nil, 0, 1, false), 7, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("registry-auth", modal, true, true)
+	app.tviewApp.SetFocus(userInput)
+	app.updateStatus(fmt.Sprintf("[yellow]%s requires authorization", registryHost(ref)))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	"github.com/rivo/tview"
+)
+
+// buildkitNamespace is where buildkitd's containerd worker keeps its
+// content, snapshots and exported images.
+const buildkitNamespace = "buildkit"
+
+// buildkitLabelPrefixes are the prefixes of the labels buildkit sets on
+// the content it writes, e.g. buildkit.io/compression/digest.zstd.
+var buildkitLabelPrefixes = []string{"buildkit.io/", "buildkit/"}
+
+// isBuildCache tells whether a blob of the buildkit namespace that no
+// image references is build cache: buildkit labeled it or a lease, which
+// buildkit takes for the cache records it uses, holds it.
+func isBuildCache(labels map[string]string, leased bool) bool {
+	if leased {
+		return true
+	}
+	for key := range labels {
+		for _, prefix := range buildkitLabelPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildCacheItems returns the build cache blobs of the current view that
+// can be pruned. Blobs held by a lease or the gc.root label are skipped,
+// buildkit still uses them.
+func buildCacheItems(items []interface{}) ([]ContentInfo, int64) {
+	var cache []ContentInfo
+	var size int64
+	for _, item := range items {
+		if blob, ok := item.(ContentInfo); ok && blob.BuildCache && blob.Refs != contentPinned {
+			cache = append(cache, blob)
+			size += blob.Size
+		}
+	}
+	return cache, size
+}
+
+func (app *App) pruneBuildCache() {
+	cache, size := buildCacheItems(app.allItems)
+	if len(cache) == 0 {
+		app.updateStatus("[yellow]No build cache blobs to prune")
+		return
+	}
+
+	stopCountdown := func() {}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Prune build cache in namespace '%s'?\n\nThis will delete %d blobs (%s) that no image references and no lease holds.\n\n"+
+			"buildkitd's own cache records are not updated; prefer 'buildctl prune' while it is running.\nThis action cannot be undone!",
+			app.currentNamespace, len(cache), formatSize(size))).
+		AddButtons([]string{"Prune", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-prune")
+			if buttonLabel == "Prune" {
+				app.performPruneBuildCache(cache)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Prune Build Cache ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-prune", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, []string{"Prune", "Cancel"}, app.deleteCountdown)
+	}
+}
+
+func (app *App) performPruneBuildCache(cache []ContentInfo) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	contentStore := app.client.ContentStore()
+
+	successCount := 0
+	failCount := 0
+	var freed int64
+
+	for _, blob := range cache {
+		dgst, err := digest.Parse(blob.Digest)
+		if err == nil {
+			err = contentStore.Delete(ctx, dgst)
+		}
+		if err != nil && !errdefs.IsNotFound(err) {
+			failCount++
+			continue
+		}
+		app.recordDeletion(app.currentNamespace, ResourceContent.String(), blob.Digest)
+		successCount++
+		freed += blob.Size
+	}
+
+	if failCount > 0 {
+		app.updateStatus(fmt.Sprintf("[yellow]Pruned %d build cache blobs, %d failed%s", successCount, failCount, freedNote(freed)))
+	} else {
+		app.updateStatus(fmt.Sprintf("[green]Pruned %d build cache blobs%s", successCount, freedNote(freed)))
+	}
+	app.contentChanged(app.currentNamespace)
+	app.loadItems()
+}
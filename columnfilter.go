@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// filterColumn is a column of a resource view that the column filter row
+// can match on.
+type filterColumn struct {
+	header string
+	value  func(app *App, item interface{}) string
+}
+
+// filterColumns lists the filterable text columns of each resource type,
+// in table order. Values are what the column shows, except that IDs, keys
+// and digests are matched in full, like search does.
+var filterColumns = map[ResourceType][]filterColumn{
+	ResourceImages: {
+		{"Name", func(app *App, item interface{}) string { return item.(ImageInfo).Name }},
+		{"Platform", func(app *App, item interface{}) string { return item.(ImageInfo).Platform }},
+		{"Created", func(app *App, item interface{}) string { return app.formatTime(item.(ImageInfo).CreatedAt) }},
+	},
+	ResourceContainers: {
+		{"ID", func(app *App, item interface{}) string { return item.(ContainerInfo).ID }},
+		{"Image", func(app *App, item interface{}) string { return item.(ContainerInfo).Image }},
+		{"Status", func(app *App, item interface{}) string { return item.(ContainerInfo).Status }},
+		{"Created", func(app *App, item interface{}) string { return app.formatTime(item.(ContainerInfo).CreatedAt) }},
+	},
+	ResourceTasks: {
+		{"Container ID", func(app *App, item interface{}) string { return item.(TaskInfo).ID }},
+		{"PID", func(app *App, item interface{}) string { return strconv.FormatUint(uint64(item.(TaskInfo).PID), 10) }},
+		{"Status", func(app *App, item interface{}) string { return item.(TaskInfo).Status }},
+		{"Runtime", func(app *App, item interface{}) string { return runtimeLabel(item.(TaskInfo).Runtime) }},
+	},
+	ResourceSnapshots: {
+		{"Key", func(app *App, item interface{}) string { return item.(SnapshotInfo).Key }},
+		{"Parent", func(app *App, item interface{}) string { return item.(SnapshotInfo).Parent }},
+		{"Kind", func(app *App, item interface{}) string { return item.(SnapshotInfo).Kind }},
+		{"Snapshotter", func(app *App, item interface{}) string { return item.(SnapshotInfo).Snapshotter }},
+	},
+	ResourceContent: {
+		{"Digest", func(app *App, item interface{}) string { return item.(ContentInfo).Digest }},
+		{"Refs", func(app *App, item interface{}) string { return item.(ContentInfo).Refs }},
+		{"Created", func(app *App, item interface{}) string { return app.formatTime(item.(ContentInfo).CreatedAt) }},
+	},
+}
+
+// showColumnFilters shows the column filter row above the items table,
+// or moves the focus back to it if it is already shown.
+func (app *App) showColumnFilters() {
+	if app.columnFilterRow != nil {
+		app.tviewApp.SetFocus(app.columnFilterInputs[0])
+		return
+	}
+
+	columns := filterColumns[app.currentResource]
+	inputs := make([]*tview.InputField, len(columns))
+	row := tview.NewFlex()
+	for i, column := range columns {
+		header := column.header
+		input := tview.NewInputField().
+			SetLabel(header + ": ").
+			SetLabelColor(tcell.ColorYellow)
+		input.SetChangedFunc(func(text string) {
+			if text == "" {
+				delete(app.columnFilters, header)
+			} else {
+				app.columnFilters[header] = text
+			}
+			app.pageStart = 0
+			app.filterItems()
+		})
+		// Tab moves between the inputs, Enter goes back to the table keeping
+		// the filters, Esc drops them like it does a search
+		input.SetDoneFunc(func(key tcell.Key) {
+			switch key {
+			case tcell.KeyTab:
+				app.tviewApp.SetFocus(inputs[(i+1)%len(inputs)])
+			case tcell.KeyBacktab:
+				app.tviewApp.SetFocus(inputs[(i+len(inputs)-1)%len(inputs)])
+			case tcell.KeyEscape:
+				app.hideColumnFilters()
+				app.pageStart = 0
+				app.filterItems()
+				app.tviewApp.SetFocus(app.itemTable)
+				app.updateStatus("Column filters cleared")
+			default:
+				app.tviewApp.SetFocus(app.itemTable)
+			}
+		})
+		inputs[i] = input
+		row.AddItem(input, 0, 1, i == 0)
+	}
+
+	app.columnFilters = make(map[string]string)
+	app.columnFilterRow = row
+	app.columnFilterInputs = inputs
+	app.itemsPanel.Clear().
+		AddItem(row, 1, 0, false).
+		AddItem(app.itemTable, 0, 1, false)
+	app.tviewApp.SetFocus(inputs[0])
+	app.updateStatus("Column filters: Tab moves between columns, Enter returns to the table, Esc clears them")
+}
+
+// hideColumnFilters removes the column filter row and its filters, e.g.
+// when the resource type changes and its columns no longer apply. It
+// leaves filtering the items again to the caller.
+func (app *App) hideColumnFilters() {
+	if app.columnFilterRow == nil {
+		return
+	}
+	app.columnFilterRow = nil
+	app.columnFilterInputs = nil
+	app.columnFilters = nil
+	app.itemsPanel.Clear().
+		AddItem(app.itemTable, 0, 1, false)
+}
+
+// filterColumnValues keeps the items matching every column filter. Each
+// filter matches like search, ignoring case unless search is
+// case-sensitive.
+func (app *App) filterColumnValues(items []interface{}) []interface{} {
+	if len(app.columnFilters) == 0 {
+		return items
+	}
+
+	var kept []interface{}
+	for _, item := range items {
+		matches := true
+		for _, column := range filterColumns[app.currentResource] {
+			filter, ok := app.columnFilters[column.header]
+			if ok && !app.matchesSearch(column.value(app, item), filter) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// columnFilterNote describes the active column filters for the items
+// panel title, e.g. "Status=running, Image=nginx".
+func (app *App) columnFilterNote() string {
+	var parts []string
+	for _, column := range filterColumns[app.currentResource] {
+		if filter, ok := app.columnFilters[column.header]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%s", column.header, filter))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ColumnConfig defines an extra column of a resource view. Template is a
+// Go text/template executed on the item, e.g. {{.Name}} or
+// {{index .Labels "io.kubernetes.pod.name"}}.
+type ColumnConfig struct {
+	Header   string `json:"header"`
+	Template string `json:"template"`
+}
+
+// customColumn is a parsed ColumnConfig. A template that fails to parse
+// keeps its column, showing the error in every cell.
+type customColumn struct {
+	header string
+	tmpl   *template.Template
+	err    error
+}
+
+// parseCustomColumns parses the configured columns, keyed by resource type
+// name as shown in the resource list.
+func parseCustomColumns(config Config) map[ResourceType][]customColumn {
+	columns := make(map[ResourceType][]customColumn)
+	funcs := template.FuncMap{
+		"time": func(t time.Time) string { return formatTimestamp(config, t) },
+	}
+	for _, resource := range allResources {
+		for _, column := range config.Columns[resource.String()] {
+			tmpl, err := template.New(column.Header).Funcs(funcs).Option("missingkey=zero").Parse(column.Template)
+			columns[resource] = append(columns[resource], customColumn{header: column.Header, tmpl: tmpl, err: err})
+		}
+	}
+	return columns
+}
+
+// renderCustomColumns appends the configured columns of the current
+// resource type after its built-in ones.
+func (app *App) renderCustomColumns() {
+	columns := app.customColumns[app.currentResource]
+	if len(columns) == 0 {
+		return
+	}
+
+	first := app.itemTable.GetColumnCount()
+	for i, column := range columns {
+		app.itemTable.SetCell(0, first+i, tview.NewTableCell(tview.Escape(column.header)).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignLeft).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	for row, item := range app.pageItems() {
+		for i, column := range columns {
+			cell := tview.NewTableCell("").SetTextColor(tcell.ColorWhite)
+
+			err := column.err
+			if err == nil {
+				var b strings.Builder
+				if err = column.tmpl.Execute(&b, item); err == nil {
+					cell.SetText(tview.Escape(b.String()))
+				}
+			}
+			if err != nil {
+				cell.SetText("⚠ " + tview.Escape(err.Error())).SetTextColor(tcell.ColorRed)
+			}
+
+			app.itemTable.SetCell(row+1, first+i, cell)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Config holds settings that persist between runs. It is stored as JSON
+// in the user's config directory, e.g. ~/.config/lazyctr/config.json.
+type Config struct {
+	// ItemsPanelWeight is the width of the items panel relative to the
+	// namespace and resource panels, which have a weight of 1 each.
+	ItemsPanelWeight int `json:"items_panel_weight,omitempty"`
+
+	// AgeColors colors the Created column of containers by their age.
+	AgeColors bool `json:"age_colors,omitempty"`
+
+	// TreeViews records, per resource type name, whether the items panel
+	// shows the hierarchical view instead of the flat table.
+	TreeViews map[string]bool `json:"tree_views,omitempty"`
+
+	// RawSizes shows exact byte counts instead of rounded sizes.
+	RawSizes bool `json:"raw_sizes,omitempty"`
+
+	// ImageSize selects what the Images view size means: empty for the
+	// manifest total, "layers" or "unique".
+	ImageSize string `json:"image_size,omitempty"`
+
+	// RefreshInterval is the auto-refresh interval in seconds; 0 is off.
+	RefreshInterval int `json:"refresh_interval,omitempty"`
+
+	// ShortSnapshotKeys shows readable labels for generated snapshot keys.
+	ShortSnapshotKeys bool `json:"short_snapshot_keys,omitempty"`
+
+	// SelectFirst selects the first row whenever the items panel is
+	// redrawn instead of keeping the selected item.
+	SelectFirst bool `json:"select_first,omitempty"`
+
+	// ContainerIDs selects how container IDs are shown: empty to shorten
+	// only generated IDs, "short" or "full".
+	ContainerIDs string `json:"container_ids,omitempty"`
+
+	// NamespaceSort orders the namespace panel: empty for alphabetical,
+	// "count" for the most items first.
+	NamespaceSort string `json:"namespace_sort,omitempty"`
+
+	// ConfirmDeleteAbove is a size such as "100MB": single deletes of
+	// smaller items don't ask for confirmation. Empty always asks.
+	ConfirmDeleteAbove string `json:"confirm_delete_above,omitempty"`
+
+	// HideEmptyResources leaves resource types without items in the
+	// current namespace out of the resource panel.
+	HideEmptyResources bool `json:"hide_empty_resources,omitempty"`
+
+	// TimeFormat is the Go time layout of timestamp columns, e.g.
+	// "2006-01-02 15:04:05". Invalid layouts fall back to the default.
+	TimeFormat string `json:"time_format,omitempty"`
+
+	// UTC shows timestamps in UTC instead of local time.
+	UTC bool `json:"utc,omitempty"`
+
+	// CaseSensitiveSearch matches search queries without ignoring case.
+	CaseSensitiveSearch bool `json:"case_sensitive_search,omitempty"`
+
+	// StartFocus is the panel focused at startup: empty for the
+	// namespace panel, "resources" or "items". Only edited by hand.
+	StartFocus string `json:"start_focus,omitempty"`
+
+	// CrossNamespaceDeleteAll allows Delete All on items of several
+	// namespaces, each acknowledged separately. Only edited by hand.
+	CrossNamespaceDeleteAll bool `json:"cross_namespace_delete_all,omitempty"`
+
+	// Columns adds columns to resource views, keyed by resource type name
+	// ("Images", "Containers", ...). Only edited by hand.
+	Columns map[string][]ColumnConfig `json:"columns,omitempty"`
+}
+
+const (
+	defaultItemsPanelWeight = 3
+	minItemsPanelWeight     = 1
+	maxItemsPanelWeight     = 10
+)
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lazyctr", "config.json"), nil
+}
+
+// loadConfig reads the config file, falling back to defaults for anything
+// missing. A missing or unreadable file is not an error for the UI.
+func loadConfig() Config {
+	config := Config{ItemsPanelWeight: defaultItemsPanelWeight}
+
+	path, err := configPath()
+	if err != nil {
+		return config
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{ItemsPanelWeight: defaultItemsPanelWeight}
+	}
+
+	if !slices.Contains(refreshIntervals, time.Duration(config.RefreshInterval)*time.Second) {
+		config.RefreshInterval = 0
+	}
+	if !slices.Contains(imageSizeModes, config.ImageSize) {
+		config.ImageSize = imageSizeTotal
+	}
+	if !slices.Contains(containerIDModes, config.ContainerIDs) {
+		config.ContainerIDs = containerIDsAuto
+	}
+	if !slices.Contains(startFocusPanels, config.StartFocus) {
+		config.StartFocus = startFocusNamespaces
+	}
+	if !slices.Contains(namespaceSortModes, config.NamespaceSort) {
+		config.NamespaceSort = namespaceSortName
+	}
+	if _, err := parseSize(config.ConfirmDeleteAbove); config.ConfirmDeleteAbove != "" && err != nil {
+		config.ConfirmDeleteAbove = ""
+	}
+	if config.TimeFormat != "" && !validTimeFormat(config.TimeFormat) {
+		config.TimeFormat = ""
+	}
+	if config.ItemsPanelWeight < minItemsPanelWeight || config.ItemsPanelWeight > maxItemsPanelWeight {
+		config.ItemsPanelWeight = defaultItemsPanelWeight
+	}
+	return config
+}
+
+// saveSetting saves the config after a setting changed and reports the
+// setting's new value, noting when it could not be saved so it only lasts
+// until lazyctr exits.
+func (app *App) saveSetting(setting, value string) {
+	if err := saveConfig(app.config); err != nil {
+		app.updateStatus(fmt.Sprintf("[yellow]%s: %s[white] (not saved: %v)", setting, value, err))
+		return
+	}
+	app.updateStatus(fmt.Sprintf("%s: [green]%s[white]", setting, value))
+}
+
+// saveConfig writes the config file, creating its directory if needed.
+func saveConfig(config Config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated config
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Join(err, os.Remove(tmp))
+	}
+	return nil
+}
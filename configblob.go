@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	"github.com/rivo/tview"
+)
+
+// jumpToImageConfig switches to the Content view and selects the config
+// blob of the selected image. The manifest is the one for this host, or
+// any for images of a foreign platform.
+func (app *App) jumpToImageConfig() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("show the config of")
+		return
+	}
+	info, ok := item.(ImageInfo)
+	if !ok {
+		return
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	var matcher platforms.MatchComparer = platforms.Default()
+	if info.Foreign {
+		matcher = nil
+	}
+	manifest, err := images.Manifest(ctx, app.client.ContentStore(), info.Target, matcher)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to read the manifest of %s: %v", info.Name, err))
+		return
+	}
+
+	config := manifest.Config.Digest.String()
+	app.jumpToItem(ResourceContent, ContentInfo{Digest: config})
+	if !app.selectItem(config) {
+		app.updateStatus(fmt.Sprintf("[yellow]Config blob %s of %s is not in the content store", shortDigest(config), tview.Escape(info.Name)))
+		return
+	}
+	app.updateStatus(fmt.Sprintf("Config blob of [green]%s[white]", tview.Escape(info.Name)))
+}
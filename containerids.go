@@ -0,0 +1,52 @@
+package main
+
+import "slices"
+
+// How the Containers and Tasks views show container IDs, as stored in the
+// config file.
+const (
+	containerIDsAuto  = ""
+	containerIDsShort = "short"
+	containerIDsFull  = "full"
+)
+
+var containerIDModes = []string{containerIDsAuto, containerIDsShort, containerIDsFull}
+
+var containerIDModeNames = map[string]string{
+	containerIDsAuto:  "short for generated IDs, full for names",
+	containerIDsShort: "short",
+	containerIDsFull:  "full",
+}
+
+// containerIDText returns how a container ID is shown. Short IDs keep the
+// first 12 characters, like docker; by default only the 64 hex digit IDs
+// CRI generates are shortened, while human-chosen names stay whole. Only
+// used for display; deletes and copies always use the full ID.
+func (app *App) containerIDText(id string) string {
+	switch app.config.ContainerIDs {
+	case containerIDsFull:
+		return id
+	case containerIDsAuto:
+		if !criIDPattern.MatchString(id) {
+			return id
+		}
+	}
+	if len(id) <= 12 {
+		return id
+	}
+	return id[:12]
+}
+
+// cycleContainerIDs switches between the ways of showing container IDs and
+// remembers the choice for the next run.
+func (app *App) cycleContainerIDs() {
+	next := (slices.Index(containerIDModes, app.config.ContainerIDs) + 1) % len(containerIDModes)
+	app.config.ContainerIDs = containerIDModes[next]
+
+	index := app.selectedIndex()
+	app.renderItemTable()
+	app.selectIndex(index)
+
+	mode := containerIDModeNames[app.config.ContainerIDs]
+	app.saveSetting("Container IDs", mode)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+// gcRootLabel keeps content from being garbage collected while nothing
+// else in its namespace references it.
+const gcRootLabel = "containerd.io/gc.root"
+
+func (app *App) copyContent() {
+	var blobs []ContentInfo
+	for _, item := range app.markedItems() {
+		if blob, ok := item.(ContentInfo); ok {
+			blobs = append(blobs, blob)
+		}
+	}
+	if len(blobs) == 0 {
+		item, ok := app.selectedItem()
+		if !ok {
+			app.reportNothingSelected("copy")
+			return
+		}
+		blob, ok := item.(ContentInfo)
+		if !ok {
+			return
+		}
+		blobs = append(blobs, blob)
+	}
+
+	nsInput := tview.NewInputField().
+		SetLabel("Destination namespace: ").
+		SetFieldWidth(40)
+
+	nsInput.SetDoneFunc(func(key tcell.Key) {
+		target := strings.TrimSpace(nsInput.GetText())
+		app.closeDialog("copy")
+
+		if key != tcell.KeyEnter || target == "" {
+			return
+		}
+		if target == app.currentNamespace {
+			app.showError("Destination namespace must differ from the current one")
+			return
+		}
+
+		source := app.currentNamespace
+		app.updateStatus(fmt.Sprintf("[yellow]Copying %d blobs to namespace:[white] %s", len(blobs), target))
+
+		// Run the blocking operation in a goroutine to prevent UI freeze
+		go func() {
+			copied, err := app.performCopyContent(source, target, blobs)
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				app.contentChanged(target)
+				if len(app.namespaceList.FindItems(target, "", false, false)) == 0 && copied > 0 {
+					app.namespaceList.AddItem(target, "", 0, nil)
+				}
+				if err != nil {
+					app.showError(fmt.Sprintf("Copied %d of %d blobs to %s: %v", copied, len(blobs), target, err))
+					return
+				}
+				app.updateStatus(fmt.Sprintf("[green]Copied %d blobs to namespace:[white] %s", copied, target))
+			})
+		}()
+	})
+
+	form := tview.NewForm().
+		AddFormItem(nsInput)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Copy %d blobs from %s ", len(blobs), app.currentNamespace)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 70, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("copy", modal, true, true)
+	app.tviewApp.SetFocus(nsInput)
+}
+
+// performCopyContent writes blobs of the source namespace into the target
+// namespace, creating it if needed. Copies are labeled as GC roots since
+// nothing in the target namespace references them yet.
+func (app *App) performCopyContent(source, target string, blobs []ContentInfo) (int, error) {
+	srcCtx := namespaces.WithNamespace(context.Background(), source)
+	dstCtx := namespaces.WithNamespace(context.Background(), target)
+
+	if err := app.client.NamespaceService().Create(context.Background(), target, nil); err != nil && !errdefs.IsAlreadyExists(err) {
+		return 0, err
+	}
+
+	contentStore := app.client.ContentStore()
+	labels := map[string]string{gcRootLabel: time.Now().UTC().Format(time.RFC3339)}
+
+	copied := 0
+	for _, blob := range blobs {
+		dgst, err := digest.Parse(blob.Digest)
+		if err != nil {
+			return copied, err
+		}
+
+		if err := copyBlob(srcCtx, dstCtx, contentStore, ocispec.Descriptor{Digest: dgst, Size: blob.Size}, labels); err != nil {
+			return copied, fmt.Errorf("%s: %w", blob.Digest, err)
+		}
+		copied++
+	}
+
+	return copied, nil
+}
+
+// copyBlob rewrites a blob through the content store from the namespace
+// of srcCtx into the namespace of dstCtx. containerd stores the data once;
+// the copy only adds the blob, with labels, to the destination namespace.
+func copyBlob(srcCtx, dstCtx context.Context, contentStore content.Store, desc ocispec.Descriptor, labels map[string]string) error {
+	ra, err := contentStore.ReaderAt(srcCtx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+
+	return content.WriteBlob(dstCtx, contentStore, "lazyctr-copy-"+desc.Digest.Encoded(), content.NewReader(ra), desc, content.WithLabels(labels))
+}
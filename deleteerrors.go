@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+)
+
+// deleteErrorClass groups delete failures by what the user can do about
+// them.
+type deleteErrorClass int
+
+const (
+	deleteFailed deleteErrorClass = iota
+	deleteNotFound
+	deleteInUse
+	deleteDenied
+	deleteUnavailable
+)
+
+// deleteErrorClasses lists the failure classes in the order summaries
+// show them.
+var deleteErrorClasses = []deleteErrorClass{deleteInUse, deleteDenied, deleteUnavailable, deleteFailed}
+
+func (c deleteErrorClass) String() string {
+	switch c {
+	case deleteNotFound:
+		return "already deleted"
+	case deleteInUse:
+		return "in use"
+	case deleteDenied:
+		return "permission denied"
+	case deleteUnavailable:
+		return "containerd unavailable"
+	default:
+		return "failed"
+	}
+}
+
+// classifyDeleteError sorts a delete error by the containerd error
+// definitions the client maps gRPC status codes to.
+func classifyDeleteError(err error) deleteErrorClass {
+	switch {
+	case errdefs.IsNotFound(err):
+		return deleteNotFound
+	case errdefs.IsFailedPrecondition(err), errdefs.IsConflict(err):
+		return deleteInUse
+	case errdefs.IsPermissionDenied(err), errdefs.IsUnauthorized(err):
+		return deleteDenied
+	case errdefs.IsUnavailable(err), errdefs.IsDeadlineExceeded(err):
+		return deleteUnavailable
+	default:
+		return deleteFailed
+	}
+}
+
+// deleteGuidance suggests what to do about a class of failures when
+// deleting items of a resource type. Classes without advice return "".
+func deleteGuidance(class deleteErrorClass, resource ResourceType) string {
+	switch class {
+	case deleteInUse:
+		switch resource {
+		case ResourceContainers:
+			return "A container with a task can't be deleted; delete its task in the Tasks view first, or restart it with R if it should keep running."
+		case ResourceTasks:
+			return "Running and paused tasks are refused; stop the process first, e.g. through the client that started it."
+		case ResourceSnapshots:
+			return "Snapshots with children or used by a container can't be removed; press Enter on one to see what references it, or prune with P."
+		case ResourceContent:
+			return "A lease may hold the blob, e.g. one left by an interrupted pull; press B to delete the lease and retry."
+		default:
+			return "Something still uses it; delete what references it first."
+		}
+	case deleteDenied:
+		return "containerd refused the delete. Check that lazyctr runs as a user allowed to change this namespace."
+	case deleteUnavailable:
+		return "containerd did not answer in time. Press ! to check the connection, then try again."
+	}
+	return ""
+}
+
+// deleteFailureSummary describes the failures of a bulk delete by class,
+// e.g. "2 in use, 1 permission denied".
+func deleteFailureSummary(failures map[deleteErrorClass]int) string {
+	var parts []string
+	for _, class := range deleteErrorClasses {
+		if count := failures[class]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, class))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
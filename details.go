@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	"github.com/rivo/tview"
+)
+
+func (app *App) showItemDetails() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("show")
+		return
+	}
+
+	switch v := item.(type) {
+	case ImageInfo:
+		app.showImageDetails(v)
+	case ContainerInfo:
+		app.showContainerDetails(v)
+	case SnapshotInfo:
+		app.showSnapshotReferences(v)
+	}
+}
+
+func (app *App) showImageDetails(info ImageInfo) {
+	pinned := pinnedReference(info.Name, info.Target.Digest)
+
+	warning := ""
+	if info.Foreign {
+		warning = fmt.Sprintf("\n\n[red]⚠ Built for %s; it won't run on this host (%s) without emulation.[white]",
+			info.Platform, platforms.DefaultString())
+	}
+
+	unpacked := "unknown"
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	if image, err := app.client.GetImage(ctx, info.Name); err == nil {
+		unpacked = unpackedText(app.unpackedSnapshotters(ctx, image))
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s\n\nDigest: %s\nMedia type: %s\nPlatform: %s\nUnpacked: %s\nSize: %s\n\nPinned reference:\n%s%s",
+			tview.Escape(info.Name), info.Target.Digest, info.Target.MediaType, info.Platform, unpacked, app.storageText(info), tview.Escape(pinned), warning)).
+		AddButtons([]string{"Copy Reference", "History", "Close"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonLabel == "Copy Reference" {
+				app.copyToClipboard(pinned)
+				app.updateStatus(fmt.Sprintf("[green]Copied:[white] %s", tview.Escape(pinned)))
+			}
+			app.closeDialog("details")
+			if buttonLabel == "History" {
+				app.showImageHistory(info)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" Image ")
+	app.pages.AddPage("details", modal, true, true)
+}
+
+// pinnedReference returns the fully-qualified name@digest form of an image
+// name, dropping any tag. Names that don't parse as references are used
+// as-is.
+func pinnedReference(name string, dgst digest.Digest) string {
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return fmt.Sprintf("%s@%s", name, dgst)
+	}
+
+	pinned, err := reference.WithDigest(reference.TrimNamed(named), dgst)
+	if err != nil {
+		return fmt.Sprintf("%s@%s", name, dgst)
+	}
+	return pinned.String()
+}
+
+func (app *App) showContainerDetails(info ContainerInfo) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+
+	container, err := app.client.LoadContainer(ctx, info.ID)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to load container: %v", err))
+		return
+	}
+
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to read container spec: %v", err))
+		return
+	}
+
+	raw, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to encode container spec: %v", err))
+		return
+	}
+
+	// Friendly view: environment and mounts as two-column tables
+	friendly := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+
+	row := 0
+	addSection := func(title, left, right string) {
+		if row > 0 {
+			row++ // blank line between sections
+		}
+		friendly.SetCell(row, 0, tview.NewTableCell(title).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+		row++
+		friendly.SetCell(row, 0, tview.NewTableCell(left).SetTextColor(tcell.ColorGray).SetSelectable(false))
+		friendly.SetCell(row, 1, tview.NewTableCell(right).SetTextColor(tcell.ColorGray).SetSelectable(false))
+		row++
+	}
+	addRow := func(left, right string) {
+		friendly.SetCell(row, 0, tview.NewTableCell(left).SetTextColor(tcell.ColorWhite))
+		friendly.SetCell(row, 1, tview.NewTableCell(right).SetTextColor(tcell.ColorTeal))
+		row++
+	}
+
+	addSection("Environment", "Name", "Value")
+	if spec.Process == nil || len(spec.Process.Env) == 0 {
+		addRow("-", "")
+	} else {
+		for _, env := range spec.Process.Env {
+			name, value, _ := strings.Cut(env, "=")
+			addRow(name, tview.Escape(value))
+		}
+	}
+
+	addSection("Mounts", "Destination", "Source")
+	if len(spec.Mounts) == 0 {
+		addRow("-", "")
+	} else {
+		for _, m := range spec.Mounts {
+			source := m.Source
+			if m.Type != "" {
+				source = fmt.Sprintf("%s (%s)", source, m.Type)
+			}
+			if len(m.Options) > 0 {
+				source = fmt.Sprintf("%s [%s]", source, strings.Join(m.Options, ","))
+			}
+			addRow(m.Destination, tview.Escape(source))
+		}
+	}
+
+	// Raw view: the full OCI spec as indented JSON
+	rawView := tview.NewTextView().
+		SetScrollable(true).
+		SetText(string(raw))
+
+	views := tview.NewPages().
+		AddPage("friendly", friendly, true, true).
+		AddPage("raw", rawView, true, false)
+
+	titleFor := func(mode string) string {
+		return fmt.Sprintf(" Container: %s [%s] (r: toggle view, Esc: close) ", info.ID, mode)
+	}
+
+	frame := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(views, 0, 1, true)
+	frame.SetBorder(true).
+		SetTitle(titleFor("friendly")).
+		SetTitleAlign(tview.AlignLeft)
+
+	frame.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			app.closeDialog("details")
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+			if name, _ := views.GetFrontPage(); name == "friendly" {
+				views.SwitchToPage("raw")
+				frame.SetTitle(titleFor("raw JSON"))
+			} else {
+				views.SwitchToPage("friendly")
+				frame.SetTitle(titleFor("friendly"))
+			}
+			app.tviewApp.SetFocus(views)
+			return nil
+		}
+		return event
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(frame, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 8, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("details", modal, true, true)
+	app.tviewApp.SetFocus(frame)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+func (app *App) diffImages() {
+	var marked []ImageInfo
+	for _, item := range app.markedItems() {
+		if img, ok := item.(ImageInfo); ok {
+			marked = append(marked, img)
+		}
+	}
+	if len(marked) != 2 {
+		app.updateStatus(fmt.Sprintf("[yellow]Mark exactly two images with Space to compare them[white] (%d marked)", len(marked)))
+		return
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	contentStore := app.client.ContentStore()
+
+	var manifests [2]ocispec.Manifest
+	for i, img := range marked {
+		manifest, err := images.Manifest(ctx, contentStore, img.Target, nil)
+		if err != nil {
+			app.showError(fmt.Sprintf("Failed to read manifest of %s: %v", img.Name, err))
+			return
+		}
+		manifests[i] = manifest
+	}
+
+	a, b := marked[0], marked[1]
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(imageDiffText(a, b, manifests[0], manifests[1]))
+
+	view.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Diff: %s ↔ %s (Esc: close) ", a.Name, b.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			app.closeDialog("diff")
+		}
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(view, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("diff", modal, true, true)
+	app.tviewApp.SetFocus(view)
+}
+
+// imageDiffText compares the layers of two image manifests: layers both
+// share, layers only in A, layers only in B, and the resulting size delta.
+func imageDiffText(a, b ImageInfo, manifestA, manifestB ocispec.Manifest) string {
+	inA := make(map[string]bool)
+	for _, layer := range manifestA.Layers {
+		inA[layer.Digest.String()] = true
+	}
+	inB := make(map[string]bool)
+	for _, layer := range manifestB.Layers {
+		inB[layer.Digest.String()] = true
+	}
+
+	var shared, onlyA, onlyB []ocispec.Descriptor
+	var sharedSize, onlyASize, onlyBSize int64
+	for _, layer := range manifestA.Layers {
+		if inB[layer.Digest.String()] {
+			shared = append(shared, layer)
+			sharedSize += layer.Size
+		} else {
+			onlyA = append(onlyA, layer)
+			onlyASize += layer.Size
+		}
+	}
+	for _, layer := range manifestB.Layers {
+		if !inA[layer.Digest.String()] {
+			onlyB = append(onlyB, layer)
+			onlyBSize += layer.Size
+		}
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "[yellow]A:[white] %s (%d layers, %s)\n", tview.Escape(a.Name), len(manifestA.Layers), formatSize(a.Size))
+	fmt.Fprintf(&text, "[yellow]B:[white] %s (%d layers, %s)\n", tview.Escape(b.Name), len(manifestB.Layers), formatSize(b.Size))
+
+	delta := b.Size - a.Size
+	deltaColor, sign := "green", "-"
+	if delta > 0 {
+		deltaColor, sign = "red", "+"
+	} else {
+		delta = -delta
+	}
+	fmt.Fprintf(&text, "[yellow]Size delta (B - A):[%s] %s%s[white]\n", deltaColor, sign, formatSize(delta))
+
+	if manifestA.Config.Digest == manifestB.Config.Digest {
+		text.WriteString("[green]Same image config[white]\n")
+	}
+
+	sections := []struct {
+		title  string
+		color  string
+		layers []ocispec.Descriptor
+		size   int64
+	}{
+		{"Shared layers", "gray", shared, sharedSize},
+		{"Only in A", "red", onlyA, onlyASize},
+		{"Only in B", "green", onlyB, onlyBSize},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&text, "\n[yellow]%s: %d (%s)[white]\n", section.title, len(section.layers), formatSize(section.size))
+		for _, layer := range section.layers {
+			fmt.Fprintf(&text, "  [%s]%s[white]  %s\n", section.color, layer.Digest, formatSize(layer.Size))
+		}
+	}
+
+	return text.String()
+}
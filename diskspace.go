@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+const (
+	// lowSpaceFraction and lowSpaceBytes are the free space on the data
+	// root below which lazyctr warns before pulls.
+	lowSpaceFraction = 0.1
+	lowSpaceBytes    = 2 << 30
+)
+
+const (
+	// pullSizeTimeout bounds the registry requests that size a pull before
+	// the disk space check.
+	pullSizeTimeout = 15 * time.Second
+	// largePullBytes is more than any plausible image download; with this
+	// much to spare, pulls don't look up their size.
+	largePullBytes = 20 << 30
+)
+
+// diskSpace is the size of a filesystem and the space unprivileged
+// writers may still use on it.
+type diskSpace struct {
+	free  int64
+	total int64
+}
+
+func (d diskSpace) low() bool {
+	return d.free < lowSpaceBytes || float64(d.free) < lowSpaceFraction*float64(d.total)
+}
+
+func statDisk(path string) (diskSpace, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return diskSpace{}, err
+	}
+	return diskSpace{
+		free:  int64(fs.Bavail) * int64(fs.Bsize),
+		total: int64(fs.Blocks) * int64(fs.Bsize),
+	}, nil
+}
+
+// detectDataRoot records containerd's root directory, the parent of the
+// content store, whose filesystem pulls fill. It stays empty when
+// introspection is unavailable.
+func (app *App) detectDataRoot() {
+	content, err := app.pluginExports("io.containerd.content.v1")
+	if err != nil {
+		return
+	}
+	if contentRoot := content["content"]["root"]; contentRoot != "" {
+		app.dataRoot = filepath.Dir(contentRoot)
+	}
+}
+
+// dataRootSpace returns the free space on the filesystem of containerd's
+// root. It fails when the root is unknown or not visible to lazyctr, e.g.
+// when it runs in another mount namespace than containerd.
+func (app *App) dataRootSpace() (diskSpace, error) {
+	if app.dataRoot == "" {
+		return diskSpace{}, fmt.Errorf("containerd root unknown: %s", app.introspectionNote())
+	}
+	return statDisk(app.dataRoot)
+}
+
+// freeSpaceNote shows the free space on containerd's root in the status
+// bar, in red when it is low.
+func (app *App) freeSpaceNote() string {
+	space, err := app.dataRootSpace()
+	if err != nil {
+		return ""
+	}
+	color := "green"
+	if space.low() {
+		color = "red"
+	}
+	return fmt.Sprintf(" | Free: [%s]%s[white]", color, formatSize(space.free))
+}
+
+// confirmLowSpacePull runs pull right away, or first asks when the pull
+// would leave the filesystem of containerd's root low on space. Only if
+// the free space is close enough to matter is the download size looked up,
+// from the manifest for platform on the registry; if it can't be resolved,
+// e.g. without credentials, the check is on the free space alone.
+func (app *App) confirmLowSpacePull(namespace, ref string, platform ocispec.Platform, pull func()) {
+	space, err := app.dataRootSpace()
+	if err != nil {
+		pull()
+		return
+	}
+	// No registry round trip when even a huge image leaves enough space
+	if !(diskSpace{free: space.free - largePullBytes, total: space.total}).low() {
+		pull()
+		return
+	}
+
+	app.updateStatus(fmt.Sprintf("[yellow]Checking the size of %s...", ref))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		size, sizeErr := app.pullSize(namespace, ref, platform)
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			after := space
+			if sizeErr == nil {
+				after.free -= size
+			}
+			if !after.low() {
+				pull()
+				return
+			}
+
+			text := fmt.Sprintf("Only %s of %s is free on %s.\n\nPulling %s may fill the disk",
+				formatSize(space.free), formatSize(space.total), tview.Escape(app.dataRoot), tview.Escape(ref))
+			if sizeErr == nil {
+				text = fmt.Sprintf("%s of %s is free on %s, and pulling %s downloads %s, leaving %s.\n\nThe pull may fill the disk",
+					formatSize(space.free), formatSize(space.total), tview.Escape(app.dataRoot), tview.Escape(ref), formatSize(size), formatSize(max(after.free, 0)))
+			}
+			text += ", which stalls containerd and everything writing there. Free space first, e.g. by pruning unused images and snapshots."
+
+			buttons := []string{"Pull Anyway", "Cancel"}
+			modal := tview.NewModal().
+				SetText(text).
+				AddButtons(buttons).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					app.closeDialog("confirm-space")
+					if buttonLabel == "Pull Anyway" {
+						pull()
+					}
+				})
+
+			modal.SetBorder(true).SetTitle(" ⚠ Low Disk Space ")
+			modal.SetBackgroundColor(tcell.ColorDefault)
+			app.pages.AddPage("confirm-space", modal, true, true)
+		})
+	}()
+}
+
+// pullSize resolves ref on its registry and sums the config and layers of
+// its manifest for platform, which is what a pull downloads. Unpacking
+// takes more space on top of it. An image already present in namespace
+// downloads nothing, so the registry isn't asked.
+func (app *App) pullSize(namespace, ref string, platform ocispec.Platform) (int64, error) {
+	ctx, cancel := context.WithTimeout(namespaces.WithNamespace(context.Background(), namespace), pullSizeTimeout)
+	defer cancel()
+
+	named, err := reference.ParseDockerRef(ref)
+	if err != nil {
+		return 0, err
+	}
+	if present, err := app.imagePresent(ctx, named.String(), platform); err == nil && present {
+		return 0, nil
+	}
+	resolver := app.newResolver(nil)
+	name, desc, err := resolver.Resolve(ctx, named.String())
+	if err != nil {
+		return 0, err
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	if images.IsIndexType(desc.MediaType) {
+		var index ocispec.Index
+		if err := fetchJSON(ctx, fetcher, desc, &index); err != nil {
+			return 0, err
+		}
+		matcher := platforms.Only(platform)
+		var found *ocispec.Descriptor
+		for i, manifest := range index.Manifests {
+			if manifest.Platform == nil || !matcher.Match(*manifest.Platform) {
+				continue
+			}
+			if found == nil || matcher.Less(*manifest.Platform, *found.Platform) {
+				found = &index.Manifests[i]
+			}
+		}
+		if found == nil {
+			return 0, fmt.Errorf("no manifest for %s", platforms.Format(platform))
+		}
+		desc = *found
+	}
+	if !images.IsManifestType(desc.MediaType) {
+		return 0, fmt.Errorf("unexpected media type %s", desc.MediaType)
+	}
+
+	var manifest ocispec.Manifest
+	if err := fetchJSON(ctx, fetcher, desc, &manifest); err != nil {
+		return 0, err
+	}
+	size := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+	return size, nil
+}
+
+// fetchJSON decodes an index or manifest fetched from a registry.
+func fetchJSON(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor, v interface{}) error {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return json.NewDecoder(io.LimitReader(rc, desc.Size)).Decode(v)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// dockerHubAuthKey is where docker stores Docker Hub credentials.
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfig is the part of the Docker CLI config file that holds
+// registry credentials.
+type dockerConfig struct {
+	Auths       map[string]dockerAuth `json:"auths"`
+	CredsStore  string                `json:"credsStore"`
+	CredHelpers map[string]string     `json:"credHelpers"`
+}
+
+type dockerAuth struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// dockerConfigPath returns $DOCKER_CONFIG/config.json, or
+// ~/.docker/config.json when DOCKER_CONFIG is unset.
+func dockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// loadDockerConfig reads the Docker CLI config. A missing file is an empty
+// config.
+func loadDockerConfig() (dockerConfig, error) {
+	var config dockerConfig
+
+	path, err := dockerConfigPath()
+	if err != nil {
+		return config, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// dockerAuthKey normalizes a registry host the way docker keys its
+// credentials; Docker Hub is known by several names.
+func dockerAuthKey(host string) string {
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io":
+		return dockerHubAuthKey
+	}
+	return host
+}
+
+// dockerConfigHost reduces a key of the auths section, which may be a URL,
+// to its host.
+func dockerConfigHost(key string) string {
+	if key == dockerHubAuthKey {
+		return key
+	}
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, _, _ := strings.Cut(key, "/")
+	return dockerAuthKey(host)
+}
+
+// dockerCredentials looks up stored credentials for a registry host: from
+// its credential helper, else the default credentials store, else the
+// auths section. It returns nil when nothing is stored for the host.
+func dockerCredentials(host string) (*registryCredentials, error) {
+	config, err := loadDockerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	key := dockerAuthKey(host)
+	helper := config.CredHelpers[host]
+	if helper == "" {
+		helper = config.CredHelpers[key]
+	}
+	if helper == "" {
+		helper = config.CredsStore
+	}
+	if helper != "" {
+		creds, err := credentialHelperGet(helper, key)
+		if creds != nil || err != nil {
+			return creds, err
+		}
+	}
+
+	for configKey, auth := range config.Auths {
+		if dockerConfigHost(configKey) != key {
+			continue
+		}
+		return decodeDockerAuth(auth)
+	}
+	return nil, nil
+}
+
+func decodeDockerAuth(auth dockerAuth) (*registryCredentials, error) {
+	creds := &registryCredentials{username: auth.Username, secret: auth.Password}
+	if auth.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth in docker config: %w", err)
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid auth in docker config")
+		}
+		creds.username, creds.secret = username, password
+	}
+	if auth.IdentityToken != "" {
+		// An empty username makes the authorizer use the token as refresh token
+		creds.username, creds.secret = "", auth.IdentityToken
+	}
+	if creds.secret == "" {
+		return nil, nil
+	}
+	return creds, nil
+}
+
+// credentialHelperGet runs docker-credential-<helper> get, which reads the
+// server address on stdin. A helper without credentials for the server is
+// not an error.
+func credentialHelperGet(helper, server string) (*registryCredentials, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(output, "credentials not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("credential helper %s: %v: %s", helper, err, output)
+	}
+
+	var result struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("credential helper %s: %w", helper, err)
+	}
+	if result.Username == "<token>" {
+		// Helpers store identity tokens under this placeholder username
+		result.Username = ""
+	}
+	return &registryCredentials{username: result.Username, secret: result.Secret}, nil
+}
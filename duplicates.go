@@ -0,0 +1,208 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// duplicateGroup is a set of image names that resolve to the same content.
+type duplicateGroup struct {
+	digest string
+	size   int64
+	names  []string
+}
+
+// duplicateImages groups the images of items by target digest, keeping
+// only the digests tagged more than once, largest first.
+func duplicateImages(items []interface{}) []duplicateGroup {
+	byDigest := make(map[string]*duplicateGroup)
+	var order []string
+	for _, item := range items {
+		img, ok := item.(ImageInfo)
+		if !ok || img.Target.Digest == "" {
+			continue
+		}
+		dgst := img.Target.Digest.String()
+		group, ok := byDigest[dgst]
+		if !ok {
+			group = &duplicateGroup{digest: dgst, size: img.Size}
+			byDigest[dgst] = group
+			order = append(order, dgst)
+		}
+		group.names = append(group.names, img.Name)
+	}
+
+	var groups []duplicateGroup
+	for _, dgst := range order {
+		if group := byDigest[dgst]; len(group.names) > 1 {
+			slices.Sort(group.names)
+			groups = append(groups, *group)
+		}
+	}
+	slices.SortStableFunc(groups, func(a, b duplicateGroup) int {
+		return cmp.Compare(b.size, a.size)
+	})
+	return groups
+}
+
+// duplicateCounts maps each duplicated image name to the number of other
+// names sharing its content.
+func duplicateCounts(groups []duplicateGroup) map[string]int {
+	counts := make(map[string]int)
+	for _, group := range groups {
+		for _, name := range group.names {
+			counts[name] = len(group.names) - 1
+		}
+	}
+	return counts
+}
+
+// sameContentTags returns the other image names in items that resolve to
+// the same content as img.
+func sameContentTags(items []interface{}, img ImageInfo) []string {
+	var names []string
+	for _, item := range items {
+		other, ok := item.(ImageInfo)
+		if ok && other.Name != img.Name && other.Target.Digest == img.Target.Digest {
+			names = append(names, other.Name)
+		}
+	}
+	return names
+}
+
+// imageDeleteNote explains what deleting an image removes: always the
+// name, and its content only when no other tag points at it. Content no
+// image references anymore is garbage collected as part of the delete.
+func (app *App) imageDeleteNote(img ImageInfo) string {
+	others := sameContentTags(app.allItems, img)
+	switch {
+	case len(others) == 1:
+		return fmt.Sprintf("This only removes the name. %s points at the same content, so the content stays and no space is freed. Press & to delete all of them.",
+			tview.Escape(others[0]))
+	case len(others) > 1:
+		return fmt.Sprintf("This only removes the name. %d other tags point at the same content, so the content stays and no space is freed. Press & to delete all of them.",
+			len(others))
+	case img.SharedLayers > 0 && !img.Sizing && !app.imageSizesPending:
+		return fmt.Sprintf("No other tag points at its content, so it is garbage collected right away, except for the %d of %d layers other images share.",
+			img.SharedLayers, img.Layers)
+	}
+	return "No other tag points at its content, so it is garbage collected right away. This cannot be undone, short of pulling the image again."
+}
+
+// showDuplicateImages lists the images of the current namespace that share
+// their content with other tags, starting at the group of the selected
+// image. Enter jumps to a tag.
+func (app *App) showDuplicateImages() {
+	groups := duplicateImages(app.allItems)
+
+	selected := ""
+	if item, ok := app.selectedItem(); ok {
+		selected = item.(ImageInfo).Name
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+
+	// rows maps table rows to image names; group headers map to ""
+	var rows []string
+	selectRow := 0
+	for _, group := range groups {
+		table.SetCell(len(rows), 0, tview.NewTableCell(fmt.Sprintf("%s  %s  %d tags", shortDigest(group.digest), app.sizeText(group.size), len(group.names))).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false))
+		rows = append(rows, "")
+
+		for _, name := range group.names {
+			if name == selected {
+				selectRow = len(rows)
+			}
+			table.SetCell(len(rows), 0, tview.NewTableCell("  "+tview.Escape(name)).SetTextColor(tcell.ColorWhite))
+			rows = append(rows, name)
+		}
+	}
+	if len(groups) == 0 {
+		table.SetCell(0, 0, tview.NewTableCell("Every image in this namespace has distinct content").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+	} else if selectRow == 0 {
+		selectRow = 1
+	}
+	table.Select(selectRow, 0)
+
+	closeDuplicates := func() {
+		app.closeDialog("duplicates")
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row < 0 || row >= len(rows) || rows[row] == "" {
+			return
+		}
+		name := rows[row]
+		closeDuplicates()
+		app.jumpToItem(ResourceImages, ImageInfo{Name: name})
+	})
+
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			closeDuplicates()
+		}
+	})
+
+	// d deletes every tag of the group of the selected row
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() != 'd' {
+			return event
+		}
+		row, _ := table.GetSelection()
+		if row < 0 || row >= len(rows) {
+			return nil
+		}
+		for _, group := range groups {
+			if slices.Contains(group.names, rows[row]) {
+				closeDuplicates()
+				app.confirmDeleteContentGroup(group.names, group.size)
+				break
+			}
+		}
+		return nil
+	})
+
+	note := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[gray]Tags in a group share all their content: deleting one of them frees nothing until the last is deleted.")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(note, 1, 0, false)
+	layout.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Duplicate Content [%s]: %d groups (Enter: jump, d: delete group, Esc: close) ", app.currentNamespace, len(groups))).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(layout, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("duplicates", modal, true, true)
+	app.tviewApp.SetFocus(table)
+}
+
+// shortDigest abbreviates a digest to its algorithm and first 12 hex
+// digits, like docker does.
+func shortDigest(dgst string) string {
+	algorithm, encoded, ok := strings.Cut(dgst, ":")
+	if !ok || len(encoded) <= 12 {
+		return dgst
+	}
+	return algorithm + ":" + encoded[:12]
+}
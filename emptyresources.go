@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// countResources counts the items of each resource type of the current
+// namespace in the background, then shows the counts in the resource
+// panel and hides the empty types if asked to.
+func (app *App) countResources() {
+	if app.currentNamespace == "" {
+		return
+	}
+	namespace := app.currentNamespace
+	snapshotters := app.shownSnapshotters()
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		counts, err := app.countResourceItems(namespace, snapshotters)
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			if namespace != app.currentNamespace {
+				return
+			}
+			if err != nil {
+				// Show every type rather than hide on stale counts
+				counts = nil
+			}
+			app.resourceCounts = counts
+			app.fillResourceList(app.currentResource)
+		})
+	}()
+}
+
+// resourceLabel is the resource panel entry of a resource type, with its
+// item count once known.
+func (app *App) resourceLabel(resource ResourceType) string {
+	count, ok := app.resourceCounts[resource]
+	if !ok {
+		return resource.String()
+	}
+	return fmt.Sprintf("%s (%d)", resource, count)
+}
+
+// updateResourceCount sets the count of one resource type, e.g. from a
+// reload of its view, if the counts of the namespace are known.
+func (app *App) updateResourceCount(resource ResourceType, count int) {
+	if app.resourceCounts == nil || app.resourceCounts[resource] == count {
+		return
+	}
+	app.resourceCounts[resource] = count
+	app.fillResourceList(app.currentResource)
+}
+
+// clearResourceCounts forgets the counts of the previous namespace, so
+// they are never shown for another.
+func (app *App) clearResourceCounts() {
+	app.resourceCounts = nil
+	app.fillResourceList(app.currentResource)
+}
+
+// fillResourceList lists the resource types in the resource panel with
+// their counts, leaving out the ones without items when empty types are
+// hidden. The current type and keep are always listed, so the view never
+// changes under the user. Types are listed until their counts are known.
+func (app *App) fillResourceList(keep ResourceType) {
+	var shown []ResourceType
+	for _, resource := range allResources {
+		if !app.config.HideEmptyResources || app.resourceCounts == nil ||
+			app.resourceCounts[resource] > 0 || resource == app.currentResource || resource == keep {
+			shown = append(shown, resource)
+		}
+	}
+
+	title := " Resources "
+	if hidden := len(allResources) - len(shown); hidden > 0 {
+		title = fmt.Sprintf(" Resources (%d empty hidden) ", hidden)
+	}
+	app.resourceList.SetTitle(title)
+
+	if slices.Equal(shown, app.shownResources) {
+		for i, resource := range shown {
+			app.resourceList.SetItemText(i, app.resourceLabel(resource), "")
+		}
+		return
+	}
+
+	// Rebuild the list without reloading items for intermediate selections
+	app.resourceList.SetChangedFunc(nil)
+	app.resourceList.Clear()
+	for _, resource := range shown {
+		app.resourceList.AddItem(app.resourceLabel(resource), "", 0, nil)
+	}
+	app.shownResources = shown
+	app.resourceList.SetCurrentItem(slices.Index(shown, app.currentResource))
+	app.resourceList.SetChangedFunc(app.resourceChanged)
+}
+
+// selectResource switches the items panel to a resource type. A hidden
+// empty type is listed again while it is selected.
+func (app *App) selectResource(resource ResourceType) {
+	if !slices.Contains(app.shownResources, resource) {
+		app.fillResourceList(resource)
+	}
+	// Triggers the resource list changed handler, which reloads items
+	app.resourceList.SetCurrentItem(slices.Index(app.shownResources, resource))
+}
+
+// toggleHideEmptyResources switches between listing every resource type
+// and hiding the types without items in the current namespace.
+func (app *App) toggleHideEmptyResources() {
+	app.config.HideEmptyResources = !app.config.HideEmptyResources
+	app.fillResourceList(app.currentResource)
+
+	state := "shown"
+	switch {
+	case app.config.HideEmptyResources && app.resourceCounts == nil:
+		state = "hidden (counting…)"
+	case app.config.HideEmptyResources:
+		state = "hidden"
+	}
+	app.saveSetting("Empty resource types", state)
+}
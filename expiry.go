@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// gcExpireLabel holds an RFC 3339 expiration time. containerd's garbage
+// collector only honors it on leases; on images it is just a marker that
+// lazyctr surfaces and can act on.
+const gcExpireLabel = "containerd.io/gc.expire"
+
+// imageExpiry parses the expiration label of an image.
+func imageExpiry(labels map[string]string) (time.Time, bool) {
+	value, ok := labels[gcExpireLabel]
+	if !ok {
+		return time.Time{}, false
+	}
+	expires, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+// expiryText describes when an image expires, or that it has.
+func expiryText(expires time.Time) (string, tcell.Color) {
+	remaining := time.Until(expires)
+	if remaining <= 0 {
+		return "expired", tcell.ColorRed
+	}
+	return fmt.Sprintf("expires in %s", remaining.Round(time.Minute)), tcell.ColorYellow
+}
+
+func (app *App) pruneExpiredImages() {
+	var expired []ImageInfo
+	for _, item := range app.allItems {
+		if img, ok := item.(ImageInfo); ok && !img.Expires.IsZero() && time.Now().After(img.Expires) {
+			expired = append(expired, img)
+		}
+	}
+	if len(expired) == 0 {
+		app.updateStatus(fmt.Sprintf("[green]No expired images[white] (images labeled %s)", gcExpireLabel))
+		return
+	}
+
+	stopCountdown := func() {}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete %d expired images in namespace '%s'?\n\nTheir %s label lies in the past.\nThis action cannot be undone!",
+			len(expired), app.currentNamespace, gcExpireLabel)).
+		AddButtons([]string{"Delete Expired", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-expired")
+			if buttonLabel == "Delete Expired" {
+				app.performPruneExpiredImages(expired)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Delete Expired ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-expired", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, []string{"Delete Expired", "Cancel"}, app.deleteCountdown)
+	}
+}
+
+func (app *App) performPruneExpiredImages(expired []ImageInfo) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	imageService := app.client.ImageService()
+
+	successCount := 0
+	failCount := 0
+	var freed int64
+
+	for _, img := range expired {
+		err := imageService.Delete(ctx, img.Name, images.SynchronousDelete())
+		if err != nil && !errdefs.IsNotFound(err) {
+			failCount++
+			continue
+		}
+		app.recordDeletion(app.currentNamespace, ResourceImages.String(), img.Name)
+		successCount++
+		freed += img.Size
+	}
+
+	if failCount > 0 {
+		app.updateStatus(fmt.Sprintf("[yellow]Deleted %d expired images, %d failed%s", successCount, failCount, freedNote(freed)))
+	} else {
+		app.updateStatus(fmt.Sprintf("[green]Deleted %d expired images%s", successCount, freedNote(freed)))
+	}
+	app.contentChanged(app.currentNamespace)
+	app.loadItems()
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+func (app *App) exportBlob() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("export")
+		return
+	}
+
+	blob, ok := item.(ContentInfo)
+	if !ok {
+		return
+	}
+
+	pathInput := tview.NewInputField().
+		SetLabel("Save to: ").
+		SetFieldWidth(60).
+		SetText(strings.ReplaceAll(blob.Digest, ":", "-"))
+
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		path := strings.TrimSpace(pathInput.GetText())
+		app.closeDialog("export")
+
+		if key != tcell.KeyEnter || path == "" {
+			return
+		}
+
+		namespace := app.currentNamespace
+		app.updateStatus(fmt.Sprintf("[yellow]Exporting:[white] %s → %s", blob.Digest, path))
+
+		// Run the blocking operation in a goroutine to prevent UI freeze
+		go func() {
+			err := app.performExportBlob(namespace, blob, path)
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				if err != nil {
+					app.showError(fmt.Sprintf("Failed to export %s: %v", blob.Digest, err))
+					return
+				}
+				app.updateStatus(fmt.Sprintf("[green]Exported:[white] %s → %s (%s)", blob.Digest, path, formatSize(blob.Size)))
+			})
+		}()
+	})
+
+	form := tview.NewForm().
+		AddFormItem(pathInput)
+
+	form.SetBorder(true).
+		SetTitle(" Export Blob ").
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("export", modal, true, true)
+	app.tviewApp.SetFocus(pathInput)
+}
+
+// performExportBlob streams a blob from the content store to path,
+// reporting progress in the status bar. A partially written file is
+// removed on failure.
+func (app *App) performExportBlob(namespace string, blob ContentInfo, path string) error {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	dgst, err := digest.Parse(blob.Digest)
+	if err != nil {
+		return err
+	}
+
+	ra, err := app.client.ContentStore().ReaderAt(ctx, ocispec.Descriptor{Digest: dgst, Size: blob.Size})
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var written atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				n := written.Load()
+				app.tviewApp.QueueUpdateDraw(func() {
+					app.updateStatus(fmt.Sprintf("[yellow]Exporting:[white] %s %s / %s", blob.Digest, formatSize(n), formatSize(blob.Size)))
+				})
+			}
+		}
+	}()
+
+	_, err = io.Copy(&countingWriter{w: f, n: &written}, content.NewReader(ra))
+	close(done)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+)
+
+// healthTimeout bounds each round trip of the health check, so a hung
+// daemon is reported instead of waited on.
+const healthTimeout = 5 * time.Second
+
+// checkHealth asks containerd for its version and reports the server
+// version and round-trip time. If the daemon doesn't answer, the
+// connection is re-established and asked once more.
+func (app *App) checkHealth() {
+	app.updateStatus("[yellow]Checking containerd...[white]")
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		version, latency, err := app.pingDaemon()
+		var reconnectErr error
+		reconnected := false
+		if err != nil {
+			if reconnectErr = app.client.Reconnect(); reconnectErr == nil {
+				reconnected = true
+				version, latency, reconnectErr = app.pingDaemon()
+			}
+		}
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			switch {
+			case reconnectErr != nil:
+				app.showError(fmt.Sprintf("containerd is not responding: %v\n\nReconnecting failed: %v%s", err, reconnectErr, permissionHint(reconnectErr)))
+			case reconnected:
+				app.updateStatus(fmt.Sprintf("[yellow]Reconnected[white] to containerd %s after: %v (round trip %s)",
+					version.Version, err, latency))
+				app.loadItems()
+			default:
+				app.updateStatus(fmt.Sprintf("containerd [green]healthy[white]: %s (%s), round trip %s",
+					version.Version, shortRevision(version.Revision), latency))
+			}
+		})
+	}()
+}
+
+// pingDaemon makes one timed version round trip to containerd.
+func (app *App) pingDaemon() (containerd.Version, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+
+	start := time.Now()
+	version, err := app.client.Version(ctx)
+	return version, time.Since(start).Round(10 * time.Microsecond), err
+}
+
+// shortRevision shortens a commit hash for display.
+func shortRevision(revision string) string {
+	if len(revision) > 12 {
+		return revision[:12]
+	}
+	return revision
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// highlightStep is how long each step of the change highlight lasts; the
+// highlight fades out over all steps.
+const highlightStep = 350 * time.Millisecond
+
+// Colors of the change highlight, from brightest to faintest.
+var (
+	addedHighlight   = []tcell.Color{tcell.ColorDarkGreen, tcell.NewRGBColor(0, 72, 0), tcell.NewRGBColor(0, 40, 0)}
+	changedHighlight = []tcell.Color{tcell.ColorOlive, tcell.NewRGBColor(72, 72, 0), tcell.NewRGBColor(40, 40, 0)}
+)
+
+// viewKey identifies what a load showed, so reloads of the same view can
+// be told apart from switching to another one.
+func (app *App) viewKey(filters []string) string {
+	key := app.currentNamespace + "/" + app.currentResource.String()
+	for _, filter := range filters {
+		key += "\x00" + filter
+	}
+	return key
+}
+
+// highlightChanges compares a reload of the current view with what it
+// showed before and highlights the items that are new or changed. The
+// highlight fades out after about a second.
+func (app *App) highlightChanges(previous, current []interface{}) {
+	before := make(map[string]interface{}, len(previous))
+	for _, item := range previous {
+		before[itemID(item)] = item
+	}
+
+	changed := make(map[string]bool)
+	for _, item := range current {
+		id := itemID(item)
+		old, ok := before[id]
+		switch {
+		case !ok:
+			changed[id] = true
+		case !reflect.DeepEqual(comparableItem(old), comparableItem(item)):
+			changed[id] = false
+		}
+	}
+
+	app.highlightGen++
+	app.highlightStage = 0
+	app.changedItems = changed
+	if len(changed) == 0 {
+		return
+	}
+
+	gen := app.highlightGen
+	go func() {
+		for stage := 1; stage <= len(addedHighlight); stage++ {
+			time.Sleep(highlightStep)
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				if gen != app.highlightGen {
+					return
+				}
+				app.highlightStage = stage
+				app.paintHighlights()
+				if stage == len(addedHighlight) {
+					app.changedItems = nil
+				}
+			})
+		}
+	}()
+}
+
+// comparableItem strips the fields of an item that are filled in after it is
+// listed, so that they don't count as changes.
+func comparableItem(item interface{}) interface{} {
+	if img, ok := item.(ImageInfo); ok {
+		img.Sizing = false
+		img.LayersSize, img.UniqueSize = 0, 0
+		img.Layers, img.SharedLayers = 0, 0
+		return img
+	}
+	return item
+}
+
+// paintHighlights colors the rows of changed items for the current stage
+// of the fade, or clears them once it is over.
+func (app *App) paintHighlights() {
+	if len(app.changedItems) == 0 {
+		return
+	}
+
+	for i, item := range app.pageItems() {
+		added, ok := app.changedItems[itemID(item)]
+		if !ok {
+			continue
+		}
+
+		colors := changedHighlight
+		if added {
+			colors = addedHighlight
+		}
+		for column := 0; column < app.itemTable.GetColumnCount(); column++ {
+			cell := app.itemTable.GetCell(i+1, column)
+			if app.highlightStage < len(colors) {
+				cell.SetBackgroundColor(colors[app.highlightStage])
+			} else {
+				cell.SetBackgroundColor(tview.Styles.PrimitiveBackgroundColor).SetTransparency(true)
+			}
+		}
+	}
+}
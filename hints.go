@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// resourceAction is an Items panel action that only applies to some
+// resource types, listed on the hint line of the help bar.
+type resourceAction struct {
+	key       string
+	name      string
+	resources []ResourceType
+	// available, when set, hides the action where it does nothing, e.g.
+	// outside the namespace it works in.
+	available func(app *App) bool
+}
+
+// resourceActions lists the resource-specific actions in the order they
+// are hinted. Keep it in sync with the key handler in initUI's input
+// capture.
+var resourceActions = []resourceAction{
+	{key: "p", name: "Pull", resources: []ResourceType{ResourceImages}},
+	{key: "P", name: "Push", resources: []ResourceType{ResourceImages}},
+	{key: "t", name: "Tag", resources: []ResourceType{ResourceImages}},
+	{key: "m", name: "Retag Many", resources: []ResourceType{ResourceImages}},
+	{key: "R", name: "Run", resources: []ResourceType{ResourceImages}},
+	{key: "=", name: "Diff Marked", resources: []ResourceType{ResourceImages}},
+	{key: "V", name: "Verify", resources: []ResourceType{ResourceImages}},
+	{key: "U", name: "Shared Tags", resources: []ResourceType{ResourceImages}},
+	{key: "&", name: "Delete Shared Tags", resources: []ResourceType{ResourceImages}},
+	{key: "O", name: "Unused Only", resources: []ResourceType{ResourceImages}},
+	{key: "E", name: "Older Than", resources: []ResourceType{ResourceImages}},
+	{key: "X", name: "Prune Expired", resources: []ResourceType{ResourceImages}},
+	{key: "M", name: "Manifest", resources: []ResourceType{ResourceImages}},
+	{key: "y", name: "Layer Digests", resources: []ResourceType{ResourceImages}},
+	{key: "J", name: "Config Blob", resources: []ResourceType{ResourceImages}},
+	{key: "S", name: "Size Mode", resources: []ResourceType{ResourceImages}},
+	{key: "L", name: "Logs", resources: []ResourceType{ResourceContainers, ResourceTasks}},
+	{key: "t", name: "Top", resources: []ResourceType{ResourceTasks}},
+	{key: "w", name: "Watch", resources: []ResourceType{ResourceContainers, ResourceTasks}},
+	{key: "R", name: "Restart", resources: []ResourceType{ResourceContainers, ResourceTasks}},
+	{key: "I", name: "IDs", resources: []ResourceType{ResourceContainers, ResourceTasks}},
+	{key: "C", name: "Age Colors", resources: []ResourceType{ResourceContainers}},
+	{key: "s", name: "All Snapshotters", resources: []ResourceType{ResourceSnapshots}},
+	{key: "P", name: "Prune", resources: []ResourceType{ResourceSnapshots}},
+	{key: "K", name: "Short Keys", resources: []ResourceType{ResourceSnapshots}},
+	{key: "e", name: "Export", resources: []ResourceType{ResourceContent}},
+	{key: "c", name: "Copy", resources: []ResourceType{ResourceContent}},
+	{key: "f", name: "Full Digests", resources: []ResourceType{ResourceContent}},
+	{key: "B", name: "Delete Leases", resources: []ResourceType{ResourceContent}},
+	{key: "P", name: "Prune Cache", resources: []ResourceType{ResourceContent}, available: func(app *App) bool {
+		return app.currentNamespace == buildkitNamespace
+	}},
+}
+
+// resourceHint returns the hint line for a resource type: its name
+// followed by the actions that apply to it.
+func (app *App) resourceHint(resource ResourceType) string {
+	parts := []string{fmt.Sprintf("[teal]%s:[white]", resource)}
+	for _, action := range resourceActions {
+		if !slices.Contains(action.resources, resource) {
+			continue
+		}
+		if action.available != nil && !action.available(app) {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("[yellow]%s[white]:%s", action.key, action.name))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// maxDeleteHistory caps the deletion history; older entries are dropped.
+const maxDeleteHistory = 500
+
+// deletedItem records one deletion made from lazyctr in this session.
+type deletedItem struct {
+	namespace string
+	kind      string
+	id        string
+	deletedAt time.Time
+}
+
+// recordDeletion adds a deletion to the session history. The history only
+// lives in memory and is gone on exit.
+func (app *App) recordDeletion(namespace, kind, id string) {
+	app.deleteHistory = append(app.deleteHistory, deletedItem{
+		namespace: namespace,
+		kind:      kind,
+		id:        id,
+		deletedAt: time.Now(),
+	})
+	if len(app.deleteHistory) > maxDeleteHistory {
+		app.deleteHistory = app.deleteHistory[len(app.deleteHistory)-maxDeleteHistory:]
+	}
+}
+
+// showDeleteHistory lists what was deleted in this session, newest first.
+// Enter copies the identifier, e.g. to re-pull an image deleted by mistake.
+func (app *App) showDeleteHistory() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"Time", "Namespace", "Type", "Identifier"} {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	history := app.deleteHistory
+	for i := range history {
+		entry := history[len(history)-1-i]
+		table.SetCell(i+1, 0, tview.NewTableCell(entry.deletedAt.Format("15:04:05")).SetTextColor(tcell.ColorGray))
+		table.SetCell(i+1, 1, tview.NewTableCell(entry.namespace).SetTextColor(tcell.ColorTeal))
+		table.SetCell(i+1, 2, tview.NewTableCell(entry.kind).SetTextColor(tcell.ColorWhite))
+		table.SetCell(i+1, 3, tview.NewTableCell(tview.Escape(entry.id)).SetTextColor(tcell.ColorWhite).SetExpansion(1))
+	}
+	if len(history) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("Nothing deleted in this session").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row <= 0 || row > len(history) {
+			return
+		}
+		entry := history[len(history)-row]
+		app.copyToClipboard(entry.id)
+		app.updateStatus(fmt.Sprintf("[green]Copied:[white] %s", tview.Escape(entry.id)))
+	})
+
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			app.closeDialog("history")
+		}
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Deleted This Session: %d (Enter: copy identifier, Esc: close) ", len(history))).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("history", modal, true, true)
+	app.tviewApp.SetFocus(table)
+}
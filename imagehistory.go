@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+// historyStep is one build step of an image: a history entry of its
+// config and, unless the step left the filesystem unchanged, its layer.
+type historyStep struct {
+	created time.Time
+	command string
+	comment string
+	// size is -1 for steps without a layer
+	size int64
+}
+
+// imageHistory pairs the history of an image config with the layers of
+// its manifest, like docker history. The manifest is the one for this
+// host, or any for images of a foreign platform. Images built without a
+// history list their layers alone.
+func (app *App) imageHistory(ctx context.Context, info ImageInfo) ([]historyStep, error) {
+	contentStore := app.client.ContentStore()
+
+	var matcher platforms.MatchComparer = platforms.Default()
+	if info.Foreign {
+		matcher = nil
+	}
+	manifest, err := images.Manifest(ctx, contentStore, info.Target, matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := content.ReadBlob(ctx, contentStore, manifest.Config)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	var config ocispec.Image
+	if err := json.Unmarshal(blob, &config); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	var steps []historyStep
+	layers := manifest.Layers
+	for _, entry := range config.History {
+		step := historyStep{command: entry.CreatedBy, comment: entry.Comment, size: -1}
+		if entry.Created != nil {
+			step.created = *entry.Created
+		}
+		if !entry.EmptyLayer && len(layers) > 0 {
+			step.size = layers[0].Size
+			layers = layers[1:]
+		}
+		steps = append(steps, step)
+	}
+	// Layers the history doesn't account for, or all of them without one
+	for _, layer := range layers {
+		steps = append(steps, historyStep{size: layer.Size})
+	}
+	return steps, nil
+}
+
+// historyCommand shortens a build step command for display: docker
+// records RUN steps as "/bin/sh -c <command>" and other instructions as
+// "/bin/sh -c #(nop) <instruction>".
+func historyCommand(command string) string {
+	command = strings.TrimPrefix(command, "/bin/sh -c #(nop) ")
+	command = strings.TrimPrefix(command, "/bin/sh -c ")
+	return strings.Join(strings.Fields(command), " ")
+}
+
+// showImageHistory lists the build steps of an image with the size each
+// added, newest first like docker history, highlighting the largest.
+// Enter copies the full command of a step.
+func (app *App) showImageHistory(info ImageInfo) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	steps, err := app.imageHistory(ctx, info)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to read the history of %s: %v", info.Name, err))
+		return
+	}
+
+	var largest, total int64
+	for _, step := range steps {
+		largest = max(largest, step.size)
+		total += max(step.size, 0)
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"Created", "Size", "Created By"} {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	// rows lists the steps in table order, newest first
+	rows := make([]historyStep, 0, len(steps))
+	for i := len(steps) - 1; i >= 0; i-- {
+		rows = append(rows, steps[i])
+	}
+
+	for i, step := range rows {
+		row := i + 1
+		created := "-"
+		if !step.created.IsZero() {
+			created = app.formatTime(step.created)
+		}
+		table.SetCell(row, 0, tview.NewTableCell(created).SetTextColor(tcell.ColorTeal))
+
+		size := tview.NewTableCell("-").SetTextColor(tcell.ColorGray)
+		if step.size >= 0 {
+			size.SetText(app.sizeText(step.size)).SetTextColor(tcell.ColorGreen)
+			if step.size == largest && largest > 0 {
+				size.SetTextColor(tcell.ColorRed).SetAttributes(tcell.AttrBold)
+			}
+		}
+		table.SetCell(row, 1, size)
+
+		command := historyCommand(step.command)
+		switch {
+		case command == "" && step.comment != "":
+			command = "[gray]" + tview.Escape(step.comment)
+		case command == "":
+			command = "[gray](no history)"
+		default:
+			command = tview.Escape(command)
+		}
+		table.SetCell(row, 2, tview.NewTableCell(command).SetTextColor(tcell.ColorWhite).SetExpansion(1))
+	}
+	if len(rows) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("The image has no layers and no history").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+	}
+
+	closeHistory := func() {
+		app.closeDialog("image-history")
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeHistory()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row > 0 && row <= len(rows) && rows[row-1].command != "" {
+				app.copyToClipboard(rows[row-1].command)
+				app.updateStatus(fmt.Sprintf("[green]Copied:[white] %s", tview.Escape(historyCommand(rows[row-1].command))))
+			}
+			return nil
+		}
+		return event
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" History of %s: %d steps, %s (Enter: copy command, Esc: close) ",
+			tview.Escape(info.Name), len(rows), formatSize(total))).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 8, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("image-history", modal, true, true)
+	app.tviewApp.SetFocus(table)
+}
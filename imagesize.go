@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// imageSizeWorkers is how many images are measured concurrently.
+	imageSizeWorkers = 4
+
+	// imageSizeFlushInterval batches measured sizes into one redraw.
+	imageSizeFlushInterval = 250 * time.Millisecond
+)
+
+// imageSizes is what measuring an image yields. It only depends on the
+// image target, so it is cached per namespace and target digest.
+type imageSizes struct {
+	size   int64
+	layers []ocispec.Descriptor
+	// measured is false when the manifest could not be read, so the layers
+	// are unknown
+	measured bool
+}
+
+func imageSizeKey(namespace string, target digest.Digest) string {
+	return namespace + "@" + target.String()
+}
+
+// measureImage computes the sizes of an image from its manifest and caches
+// them. Images whose manifest is missing count their target only.
+func (app *App) measureImage(ctx context.Context, namespace string, img ImageInfo) imageSizes {
+	size, layers, err := app.calculateImageSize(ctx, images.Image{Name: img.Name, Target: img.Target}, app.client.ContentStore())
+	if err != nil {
+		size = img.Target.Size
+	}
+	sizes := imageSizes{size: size, layers: layers, measured: err == nil}
+
+	app.imageSizesMu.Lock()
+	defer app.imageSizesMu.Unlock()
+	if app.imageSizeCache == nil {
+		app.imageSizeCache = make(map[string]imageSizes)
+	}
+	app.imageSizeCache[imageSizeKey(namespace, img.Target.Digest)] = sizes
+	return sizes
+}
+
+// cachedImagesSize totals the cached sizes of images. ok is false unless
+// every image was measured already, e.g. by showing the Images view.
+func (app *App) cachedImagesSize(namespace string, imageList []images.Image) (total int64, ok bool) {
+	app.imageSizesMu.Lock()
+	defer app.imageSizesMu.Unlock()
+
+	for _, img := range imageList {
+		sizes, ok := app.imageSizeCache[imageSizeKey(namespace, img.Target.Digest)]
+		if !ok {
+			return 0, false
+		}
+		total += sizes.size
+	}
+	return total, len(imageList) > 0
+}
+
+// fillImageSizes fills in the cached sizes of images. The unique size
+// depends on which layers other images use, so it is only filled in once
+// every image is measured. It reports whether any image is still pending
+// and the total size of the distinct layers measured so far.
+func (app *App) fillImageSizes(namespace string, items []interface{}) (pending bool, layersTotal int64) {
+	app.imageSizesMu.Lock()
+	defer app.imageSizesMu.Unlock()
+
+	// How many distinct images use each layer
+	layerUsers := make(map[digest.Digest]map[digest.Digest]bool)
+	layerSizes := make(map[digest.Digest]int64)
+	for i, item := range items {
+		img := item.(ImageInfo)
+		sizes, ok := app.imageSizeCache[imageSizeKey(namespace, img.Target.Digest)]
+		if !ok {
+			pending = true
+			continue
+		}
+
+		img.Sizing = false
+		img.Size = sizes.size
+		img.Layers = -1
+		if sizes.measured {
+			img.Layers = len(sizes.layers)
+		}
+		img.LayersSize = 0
+		for _, layer := range sizes.layers {
+			img.LayersSize += layer.Size
+			if layerUsers[layer.Digest] == nil {
+				layerUsers[layer.Digest] = make(map[digest.Digest]bool)
+			}
+			layerUsers[layer.Digest][img.Target.Digest] = true
+			layerSizes[layer.Digest] = layer.Size
+		}
+		items[i] = img
+	}
+
+	for _, size := range layerSizes {
+		layersTotal += size
+	}
+	if pending {
+		return pending, layersTotal
+	}
+
+	for i, item := range items {
+		img := item.(ImageInfo)
+		img.UniqueSize = img.Size
+		img.SharedLayers = 0
+		for _, layer := range app.imageSizeCache[imageSizeKey(namespace, img.Target.Digest)].layers {
+			if len(layerUsers[layer.Digest]) > 1 {
+				img.UniqueSize -= layer.Size
+				img.SharedLayers++
+			}
+		}
+		items[i] = img
+	}
+	return false, layersTotal
+}
+
+// scanImageSizes measures the images of the Images view that have no size
+// yet in the background, filling the table in as sizes arrive. A scan
+// still running for an earlier load is cancelled.
+func (app *App) scanImageSizes() {
+	if app.cancelSizeScan != nil {
+		app.cancelSizeScan()
+		app.cancelSizeScan = nil
+	}
+	if app.currentResource != ResourceImages {
+		return
+	}
+
+	var pending []ImageInfo
+	for _, item := range app.allItems {
+		if img, ok := item.(ImageInfo); ok && img.Sizing {
+			pending = append(pending, img)
+		}
+	}
+	app.imageSizesPending, app.imageLayersTotal = app.fillImageSizes(app.currentNamespace, app.allItems)
+	if len(pending) == 0 {
+		return
+	}
+
+	namespace := app.currentNamespace
+	ctx, cancel := context.WithCancel(namespaces.WithNamespace(context.Background(), namespace))
+	app.cancelSizeScan = cancel
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		work := make(chan ImageInfo)
+		var wg sync.WaitGroup
+		for range imageSizeWorkers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for img := range work {
+					app.measureImage(ctx, namespace, img)
+				}
+			}()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(work)
+			for _, img := range pending {
+				select {
+				case work <- img:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		ticker := time.NewTicker(imageSizeFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-done:
+			}
+
+			finished := false
+			select {
+			case <-done:
+				finished = true
+			default:
+			}
+
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				if ctx.Err() != nil || namespace != app.currentNamespace || app.currentResource != ResourceImages {
+					return
+				}
+				app.imageSizesPending, app.imageLayersTotal = app.fillImageSizes(namespace, app.allItems)
+				index := app.selectedIndex()
+				app.filterItems()
+				app.selectIndex(index)
+			})
+
+			if finished {
+				return
+			}
+		}
+	}()
+}
+
+// storageText describes how much of an image is its own: the layers it
+// shares with other images of the namespace and the size deleting just
+// this image would reclaim.
+func (app *App) storageText(img ImageInfo) string {
+	switch {
+	case img.Sizing || app.imageSizesPending:
+		return "still being computed"
+	case img.Layers < 0:
+		return fmt.Sprintf("%s total; layers unknown, the manifest can't be read", formatSize(img.Size))
+	}
+	return fmt.Sprintf("%s total, %s not shared\nLayers: %d of %d shared with other images",
+		formatSize(img.Size), formatSize(img.UniqueSize), img.SharedLayers, img.Layers)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+)
+
+// loadedPlugins returns the IDs of the plugins of a type that loaded
+// successfully on the daemon. ok is false when introspection is
+// unavailable, e.g. denied to the user, in which case the error is kept
+// for introspectionNote and callers fall back to their defaults instead of
+// failing the view.
+func (app *App) loadedPlugins(pluginType string) (ids []string, ok bool) {
+	resp, err := app.client.IntrospectionService().Plugins(context.Background(), []string{"type==" + pluginType})
+	if err != nil {
+		if app.introspectionErr == nil {
+			app.introspectionErr = err
+		}
+		return nil, false
+	}
+
+	ids = make([]string, 0, len(resp.Plugins))
+	for _, plugin := range resp.Plugins {
+		if plugin.InitErr != nil {
+			continue
+		}
+		ids = append(ids, plugin.ID)
+	}
+	return ids, true
+}
+
+// pluginExports returns what the loaded plugins of a type export, keyed
+// by plugin ID, e.g. the "root" directory of snapshotters.
+func (app *App) pluginExports(pluginType string) (map[string]map[string]string, error) {
+	resp, err := app.client.IntrospectionService().Plugins(context.Background(), []string{"type==" + pluginType})
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make(map[string]map[string]string, len(resp.Plugins))
+	for _, plugin := range resp.Plugins {
+		if plugin.InitErr == nil {
+			exports[plugin.ID] = plugin.Exports
+		}
+	}
+	return exports, nil
+}
+
+// introspectionNote explains why a feature that relies on introspection
+// is limited.
+func (app *App) introspectionNote() string {
+	if app.introspectionErr == nil {
+		return "introspection is unavailable"
+	}
+	return "introspection is unavailable: " + app.introspectionErr.Error()
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+// copyLayerDigests offers the layer digests of the selected image, one per
+// line, to copy to the clipboard or save to a file for scanners that take
+// digest lists. Index images first ask which platform's layers to list.
+func (app *App) copyLayerDigests() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("list layers of")
+		return
+	}
+	info, ok := item.(ImageInfo)
+	if !ok {
+		return
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	img, err := app.client.ImageService().Get(ctx, info.Name)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to load %s: %v", info.Name, err))
+		return
+	}
+
+	var choices []ocispec.Platform
+	if images.IsIndexType(img.Target.MediaType) {
+		available, err := images.Platforms(ctx, app.client.ContentStore(), img.Target)
+		if err != nil {
+			app.showError(fmt.Sprintf("Failed to read platforms of %s: %v", info.Name, err))
+			return
+		}
+		// Attestation manifests in an index carry an unknown/unknown platform
+		choices = slices.DeleteFunc(available, func(p ocispec.Platform) bool {
+			return p.OS == "unknown"
+		})
+	}
+
+	// layers lists the digests for the chosen platform, or of the single
+	// manifest of a non-index image
+	layers := func(choice int) (string, error) {
+		var matcher platforms.MatchComparer
+		if len(choices) > 0 {
+			matcher = platforms.OnlyStrict(choices[choice])
+		}
+		manifest, err := images.Manifest(ctx, app.client.ContentStore(), img.Target, matcher)
+		if err != nil {
+			return "", err
+		}
+		var b strings.Builder
+		for _, layer := range manifest.Layers {
+			fmt.Fprintln(&b, layer.Digest)
+		}
+		return b.String(), nil
+	}
+
+	labels := make([]string, len(choices))
+	selected := 0
+	host := platforms.Default()
+	for i, p := range choices {
+		labels[i] = platforms.Format(p)
+		if host.Match(p) && !host.Match(choices[selected]) {
+			selected = i
+		}
+	}
+
+	closeDialog := func() {
+		app.closeDialog("layers")
+	}
+
+	form := tview.NewForm()
+	if len(choices) > 0 {
+		form.AddDropDown("Platform: ", labels, selected, nil)
+	}
+	chosen := func() int {
+		if len(choices) == 0 {
+			return 0
+		}
+		index, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		return index
+	}
+
+	form.AddButton("Copy", func() {
+		closeDialog()
+		digests, err := layers(chosen())
+		if err != nil {
+			app.showError(fmt.Sprintf("Failed to read layers of %s: %v", info.Name, err))
+			return
+		}
+		app.copyToClipboard(digests)
+		app.updateStatus(fmt.Sprintf("[green]Copied %d layer digests[white] of %s", strings.Count(digests, "\n"), info.Name))
+	})
+	form.AddButton("Save to File", func() {
+		closeDialog()
+		digests, err := layers(chosen())
+		if err != nil {
+			app.showError(fmt.Sprintf("Failed to read layers of %s: %v", info.Name, err))
+			return
+		}
+		app.saveLayerDigests(info.Name, digests)
+	})
+	form.AddButton("Cancel", closeDialog)
+	form.SetCancelFunc(closeDialog)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Layer Digests of %s ", info.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	height := 5
+	if len(choices) > 0 {
+		height = 7
+	}
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 70, 1, true).
+			AddItem(nil, 0, 1, false), height, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("layers", modal, true, true)
+	app.tviewApp.SetFocus(form)
+}
+
+// saveLayerDigests prompts for a path and writes the digest list to it.
+// Existing files are never overwritten.
+func (app *App) saveLayerDigests(name, digests string) {
+	pathInput := tview.NewInputField().
+		SetLabel("Save to: ").
+		SetFieldWidth(60).
+		SetText(layerDigestsFile(name))
+
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		file := strings.TrimSpace(pathInput.GetText())
+		app.closeDialog("layers-save")
+
+		if key != tcell.KeyEnter || file == "" {
+			return
+		}
+
+		if err := writeNewFile(file, []byte(digests)); err != nil {
+			app.showError(fmt.Sprintf("Failed to save layer digests: %v", err))
+			return
+		}
+		app.updateStatus(fmt.Sprintf("[green]Saved %d layer digests:[white] %s", strings.Count(digests, "\n"), file))
+	})
+
+	form := tview.NewForm().
+		AddFormItem(pathInput)
+
+	form.SetBorder(true).
+		SetTitle(" Save Layer Digests ").
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("layers-save", modal, true, true)
+	app.tviewApp.SetFocus(pathInput)
+}
+
+// layerDigestsFile suggests a file name for the digests of an image, e.g.
+// nginx-1.27-layers.txt for docker.io/library/nginx:1.27.
+func layerDigestsFile(name string) string {
+	base := name
+	if named, err := reference.ParseNormalizedNamed(name); err == nil {
+		base = path.Base(reference.Path(named))
+		if tagged, ok := named.(reference.Tagged); ok {
+			base += "-" + tagged.Tag()
+		}
+	}
+	base = strings.Map(func(r rune) rune {
+		if r == '/' || r == ':' || r == '@' {
+			return '-'
+		}
+		return r
+	}, base)
+	return base + "-layers.txt"
+}
+
+// writeNewFile writes data to a file that must not exist yet.
+func writeNewFile(file string, data []byte) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return errors.Join(err, f.Close())
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/leases"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	"github.com/rivo/tview"
+)
+
+// blobLeases returns the leases that hold a content blob.
+func (app *App) blobLeases(ctx context.Context, dgst digest.Digest) ([]leases.Lease, error) {
+	leaseService := app.client.LeasesService()
+	leaseList, err := leaseService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var holding []leases.Lease
+	for _, lease := range leaseList {
+		resources, err := leaseService.ListResources(ctx, lease)
+		if err != nil {
+			return nil, fmt.Errorf("lease %s: %w", lease.ID, err)
+		}
+		for _, resource := range resources {
+			if resource.Type == "content" && resource.ID == dgst.String() {
+				holding = append(holding, lease)
+				break
+			}
+		}
+	}
+	return holding, nil
+}
+
+// removeBlobLeases offers to delete the leases holding the selected blob,
+// e.g. left behind by an interrupted pull, and then the blob itself.
+func (app *App) removeBlobLeases() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("release")
+		return
+	}
+	blob, ok := item.(ContentInfo)
+	if !ok {
+		return
+	}
+
+	dgst, err := digest.Parse(blob.Digest)
+	if err != nil {
+		app.showError(fmt.Sprintf("Invalid digest %s: %v", blob.Digest, err))
+		return
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	holding, err := app.blobLeases(ctx, dgst)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to list leases: %v", err))
+		return
+	}
+	if len(holding) == 0 {
+		note := "no lease holds it"
+		if _, ok := blob.Labels["containerd.io/gc.root"]; ok {
+			note = "it is pinned by the containerd.io/gc.root label, not a lease"
+		}
+		app.updateStatus(fmt.Sprintf("[yellow]Nothing to release:[white] %s", note))
+		return
+	}
+
+	var lines []string
+	for _, lease := range holding {
+		line := fmt.Sprintf("%s (created %s)", tview.Escape(lease.ID), app.formatTime(lease.CreatedAt))
+		if expire, ok := lease.Labels["containerd.io/gc.expire"]; ok {
+			line += ", expires " + tview.Escape(expire)
+		}
+		lines = append(lines, line)
+	}
+
+	stopCountdown := func() {}
+	buttons := []string{"Delete Leases and Blob", "Delete Leases", "Cancel"}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete the %d leases holding %s?\n\n%s\n\nA lease left by an interrupted pull is safe to delete. Deleting one a pull or build still uses makes it fail.",
+			len(holding), shortDigest(blob.Digest), strings.Join(lines, "\n"))).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-leases")
+			switch buttonLabel {
+			case "Delete Leases and Blob":
+				app.performRemoveLeases(holding, blob, true)
+			case "Delete Leases":
+				app.performRemoveLeases(holding, blob, false)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Delete Leases ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-leases", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, buttons, app.deleteCountdown)
+	}
+}
+
+// performRemoveLeases deletes leases and, if asked, retries the delete of
+// the blob they held. The garbage collection after the last lease may
+// already remove an unreferenced blob.
+func (app *App) performRemoveLeases(holding []leases.Lease, blob ContentInfo, deleteBlob bool) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	leaseService := app.client.LeasesService()
+
+	for i, lease := range holding {
+		var opts []leases.DeleteOpt
+		if i == len(holding)-1 {
+			opts = append(opts, leases.SynchronousDelete)
+		}
+		if err := leaseService.Delete(ctx, lease, opts...); err != nil && classifyDeleteError(err) != deleteNotFound {
+			app.contentChanged(app.currentNamespace)
+			app.loadItems()
+			app.showError(fmt.Sprintf("Failed to delete lease %s: %v", lease.ID, err))
+			return
+		}
+		app.recordDeletion(app.currentNamespace, "Leases", lease.ID)
+	}
+
+	if !deleteBlob {
+		app.contentChanged(app.currentNamespace)
+		app.updateStatus(fmt.Sprintf("[green]Deleted %d leases[white] holding %s", len(holding), shortDigest(blob.Digest)))
+		app.loadItems()
+		return
+	}
+	app.performDelete(blob, blob.Size)
+}
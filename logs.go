@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	// criMetadataExtension is the container extension where the CRI plugin
+	// records its own metadata, including the container log path.
+	criMetadataExtension = "io.cri-containerd.container.metadata"
+
+	// maxLogTails caps how many log files are followed at once.
+	maxLogTails = 8
+
+	// logTailBacklog is how much of each log file is shown on open.
+	logTailBacklog = 16 * 1024
+
+	logPollInterval = 500 * time.Millisecond
+)
+
+// logPrefixColors cycles through distinct colors for each followed
+// container so interleaved lines stay attributable.
+var logPrefixColors = []string{"green", "yellow", "aqua", "fuchsia", "orange", "lightskyblue", "lime", "pink"}
+
+type logSource struct {
+	ID   string
+	Name string
+	Path string
+}
+
+type logLine struct {
+	source int
+	stream string
+	text   string
+}
+
+func (app *App) showLogs() {
+	var ids []string
+	for _, item := range app.markedItems() {
+		if id := containerIDOf(item); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		item, ok := app.selectedItem()
+		if !ok {
+			app.reportNothingSelected("follow")
+			return
+		}
+		if id := containerIDOf(item); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	skipped := 0
+	if len(ids) > maxLogTails {
+		skipped = len(ids) - maxLogTails
+		ids = ids[:maxLogTails]
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+
+	var sources []logSource
+	var problems []string
+	for _, id := range ids {
+		source, err := app.findLogSource(ctx, id)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		sources = append(sources, source)
+	}
+
+	if len(sources) == 0 {
+		app.showError(fmt.Sprintf("No logs available:\n\n%s", strings.Join(problems, "\n")))
+		return
+	}
+
+	logView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetMaxLines(5000)
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+
+	logView.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Logs: %s (Esc: close, f: follow) ", strings.Join(names, ", "))).
+		SetTitleAlign(tview.AlignLeft)
+
+	for _, problem := range problems {
+		fmt.Fprintf(logView, "[red]%s[-]\n", tview.Escape(problem))
+	}
+	if skipped > 0 {
+		fmt.Fprintf(logView, "[yellow]Following the first %d containers only (%d more marked)[-]\n", maxLogTails, skipped)
+	}
+
+	tailCtx, cancel := context.WithCancel(context.Background())
+	lines := make(chan logLine, 256)
+	for i, source := range sources {
+		go tailLogFile(tailCtx, source.Path, i, lines)
+	}
+	follow := &atomic.Bool{}
+	follow.Store(true)
+	go app.pumpLogLines(tailCtx, logView, sources, lines, follow)
+
+	title := fmt.Sprintf(" Logs: %s (Esc: close, f: follow) ", strings.Join(names, ", "))
+	setFollow := func(on bool) {
+		follow.Store(on)
+		if on {
+			logView.SetTitle(title)
+			logView.ScrollToEnd()
+		} else {
+			// Pin the current position; tview keeps tracking the end otherwise
+			row, column := logView.GetScrollOffset()
+			logView.ScrollTo(row, column)
+			logView.SetTitle(strings.TrimSuffix(title, " ") + " [paused] ")
+		}
+	}
+
+	logView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			cancel()
+			app.closeDialog("logs")
+			return nil
+		case tcell.KeyUp, tcell.KeyPgUp, tcell.KeyHome:
+			// Scrolling up pauses following so new lines don't yank the view
+			setFollow(false)
+		case tcell.KeyEnd:
+			setFollow(true)
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'f':
+				setFollow(!follow.Load())
+				return nil
+			case 'k', 'g':
+				setFollow(false)
+			case 'G':
+				setFollow(true)
+			}
+		}
+		return event
+	})
+
+	app.pages.AddPage("logs", logView, true, true)
+	app.tviewApp.SetFocus(logView)
+}
+
+// findLogSource resolves the CRI log file of a container from the
+// metadata the CRI plugin stores on it.
+func (app *App) findLogSource(ctx context.Context, id string) (logSource, error) {
+	container, err := app.client.LoadContainer(ctx, id)
+	if err != nil {
+		return logSource{}, err
+	}
+
+	info, err := container.Info(ctx)
+	if err != nil {
+		return logSource{}, err
+	}
+
+	metadata, err := decodeCRIMetadata(info)
+	if err != nil {
+		return logSource{}, fmt.Errorf("%w, no log file known", err)
+	}
+	if metadata.LogPath == "" {
+		return logSource{}, fmt.Errorf("container has no log path")
+	}
+
+	name := metadata.Name
+	if name == "" {
+		name = id
+	}
+
+	return logSource{ID: id, Name: name, Path: metadata.LogPath}, nil
+}
+
+// criMetadata is the part of the CRI plugin's container metadata lazyctr
+// uses.
+type criMetadata struct {
+	Name      string
+	SandboxID string
+	LogPath   string
+}
+
+// decodeCRIMetadata reads the metadata the CRI plugin stores on the
+// containers it creates.
+func decodeCRIMetadata(info containers.Container) (criMetadata, error) {
+	ext, ok := info.Extensions[criMetadataExtension]
+	if !ok || ext == nil {
+		return criMetadata{}, fmt.Errorf("not a CRI container")
+	}
+
+	var metadata struct {
+		Metadata criMetadata
+	}
+	if err := json.Unmarshal(ext.GetValue(), &metadata); err != nil {
+		return criMetadata{}, fmt.Errorf("failed to decode CRI metadata: %w", err)
+	}
+	return metadata.Metadata, nil
+}
+
+// pumpLogLines batches lines from the tailers into the view so a chatty
+// container doesn't queue a redraw per line.
+func (app *App) pumpLogLines(ctx context.Context, view *tview.TextView, sources []logSource, lines <-chan logLine, follow *atomic.Bool) {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	var buf strings.Builder
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-lines:
+			color := logPrefixColors[line.source%len(logPrefixColors)]
+			textColor := "white"
+			if line.stream == "stderr" {
+				textColor = "red"
+			}
+			prefix := ""
+			if len(sources) > 1 {
+				prefix = fmt.Sprintf("[%s]%s |[-] ", color, tview.Escape(sources[line.source].Name))
+			}
+			fmt.Fprintf(&buf, "%s[%s]%s[-]\n", prefix, textColor, tview.Escape(line.text))
+		case <-ticker.C:
+			if buf.Len() == 0 {
+				continue
+			}
+			text := buf.String()
+			buf.Reset()
+			app.tviewApp.QueueUpdateDraw(func() {
+				fmt.Fprint(view, text)
+				if follow.Load() {
+					view.ScrollToEnd()
+				}
+			})
+		}
+	}
+}
+
+// tailLogFile follows a CRI log file, starting a little before its end,
+// and reopens it from the start when kubelet rotates or truncates it.
+func tailLogFile(ctx context.Context, path string, source int, out chan<- logLine) {
+	var (
+		offset    int64 = -1
+		current   os.FileInfo
+		partial   []byte
+		skipFirst bool
+		// Partial line chunks by stream, until their final chunk
+		pending = make(map[string]string)
+	)
+
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if stat, err := os.Stat(path); err == nil {
+			if offset < 0 {
+				offset = max(stat.Size()-logTailBacklog, 0)
+				// The backlog most likely starts mid-line
+				skipFirst = offset > 0
+			} else if !os.SameFile(current, stat) || stat.Size() < offset {
+				offset = 0
+				partial = nil
+				skipFirst = false
+			}
+			current = stat
+
+			if stat.Size() > offset {
+				if data, err := readFrom(path, offset); err == nil {
+					offset += int64(len(data))
+
+					if skipFirst {
+						if i := bytes.IndexByte(data, '\n'); i >= 0 {
+							data = data[i+1:]
+							skipFirst = false
+						} else {
+							data = nil
+						}
+					}
+
+					data = append(partial, data...)
+					for {
+						i := bytes.IndexByte(data, '\n')
+						if i < 0 {
+							break
+						}
+						stream, text, partialLine := parseCRILogLine(string(data[:i]))
+						data = data[i+1:]
+						// The runtime splits long lines into P chunks ended
+						// by an F chunk; show them as the one line they are
+						if partialLine {
+							pending[stream] += text
+							continue
+						}
+						if chunks, ok := pending[stream]; ok {
+							text = chunks + text
+							delete(pending, stream)
+						}
+						select {
+						case out <- logLine{source: source, stream: stream, text: text}:
+						case <-ctx.Done():
+							return
+						}
+					}
+					partial = append([]byte(nil), data...)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func readFrom(path string, offset int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f)
+}
+
+// parseCRILogLine splits a CRI log line ("<time> <stream> <tag> <msg>")
+// into its stream and message, and reports whether the tag marks it as a
+// partial chunk of a longer line. Lines in other formats are returned
+// as-is.
+func parseCRILogLine(line string) (stream, text string, partial bool) {
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) < 3 {
+		return "", line, false
+	}
+	if _, err := time.Parse(time.RFC3339Nano, parts[0]); err != nil {
+		return "", line, false
+	}
+	// The tag is a colon-separated list of flags, P or F first
+	partial = strings.Split(parts[2], ":")[0] == "P"
+	if len(parts) == 3 {
+		return parts[1], "", partial
+	}
+	return parts[1], parts[3], partial
+}
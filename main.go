@@ -1,21 +1,37 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/containerd/containerd"
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	tasktypes "github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/snapshots"
+	"github.com/containerd/platforms"
 	"github.com/gdamore/tcell/v2"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/rivo/tview"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ResourceType int
@@ -28,6 +44,9 @@ const (
 	ResourceContent
 )
 
+// allResources lists every resource type in the order of the resource panel.
+var allResources = []ResourceType{ResourceImages, ResourceContainers, ResourceTasks, ResourceSnapshots, ResourceContent}
+
 func (r ResourceType) String() string {
 	switch r {
 	case ResourceImages:
@@ -46,28 +65,115 @@ func (r ResourceType) String() string {
 }
 
 type App struct {
-	tviewApp         *tview.Application
-	client           *containerd.Client
-	namespaceList    *tview.List
-	resourceList     *tview.List
-	itemTable        *tview.Table
-	statusBar        *tview.TextView
-	helpText         *tview.TextView
-	pages            *tview.Pages
-	currentNamespace string
-	currentResource  ResourceType
-	itemCache        []interface{}
-	allItems         []interface{}
-	searchQuery      string
-	searchInput      *tview.InputField
-	tagInput         *tview.InputField
-	snapshotter      string
+	tviewApp          *tview.Application
+	screen            tcell.Screen
+	client            *containerd.Client
+	namespaceList     *tview.List
+	resourceList      *tview.List
+	itemTable         *tview.Table
+	statusBar         *tview.TextView
+	statusMu          sync.Mutex
+	pendingStatus     string
+	lastStatus        time.Time
+	statusTimer       *time.Timer
+	helpText          *tview.TextView
+	panelFocus        tview.Primitive
+	hintText          *tview.TextView
+	pages             *tview.Pages
+	currentNamespace  string
+	currentResource   ResourceType
+	itemCache         []interface{}
+	allItems          []interface{}
+	searchQuery       string
+	searchInput       *tview.InputField
+	tagInput          *tview.InputField
+	marked            map[string]bool
+	snapshotter       string
+	snapshotters      []string
+	allSnapshotters   bool
+	deleteCountdown   int
+	countdownAll      bool
+	deleteThreshold   int64
+	skipDeleteConfirm bool
+	config            Config
+	mainFlex          *tview.Flex
+	itemsPanel        *tview.Flex
+	treePrefixes      []string
+	usageMu           sync.Mutex
+	usageCache        map[string]contentUsage
+	refsCache         map[string]contentRefs
+	contentFiltered   bool
+	refreshMu         sync.Mutex
+	refreshInterval   time.Duration
+	refreshReset      chan struct{}
+	refreshing        bool
+	followTail        bool
+	pageStart         int
+	pageSize          int
+	imageSizesMu      sync.Mutex
+	imageSizeCache    map[string]imageSizes
+	imageSizesPending bool
+	imageLayersTotal  int64
+	cancelSizeScan    context.CancelFunc
+	introspectionErr  error
+	// transferService is set when pulls can go through the daemon's
+	// transfer service
+	transferService bool
+	deleteHistory   []deletedItem
+	customColumns   map[ResourceType][]customColumn
+	// Change highlight of the last reload, by item ID: true for new items,
+	// false for changed ones
+	changedItems   map[string]bool
+	highlightGen   int
+	highlightStage int
+	loadedView     string
+	fullDigests    bool
+	// Item counts per namespace for the namespace order by count
+	namespaceCounts    map[string]int
+	countingNamespaces bool
+	// What the items panel showed when it was last drawn
+	renderedItems []interface{}
+	renderedView  string
+	// Results of verifying that images have all their content, by
+	// verificationKey
+	imageVerifications map[string]imageVerification
+	// unusedOnly hides images containers use; usedImages are their names
+	unusedOnly bool
+	usedImages map[string]bool
+	// Index into imageAgeBuckets of the Images view age filter
+	ageBucket int
+	// Resource types listed in the resource panel, in order, and the item
+	// counts of the current namespace that decide which are hidden
+	shownResources []ResourceType
+	resourceCounts map[ResourceType]int
+	// Column filter row above the items table, nil while hidden, and the
+	// filters typed into it by column header
+	columnFilterRow    *tview.Flex
+	columnFilterInputs []*tview.InputField
+	columnFilters      map[string]string
+	// containerd's root directory, empty if unknown
+	dataRoot string
 }
 
 type ImageInfo struct {
 	Name      string
 	Size      int64
 	CreatedAt time.Time
+	Target    ocispec.Descriptor
+	Platform  string
+	Foreign   bool
+	Expires   time.Time
+	// LayersSize counts only the compressed layers; UniqueSize leaves out
+	// layers other images share. Size is the manifest total.
+	LayersSize int64
+	UniqueSize int64
+	// Sizing is set while the sizes are still being computed
+	Sizing bool
+	// Layers counts the layers of the manifest; -1 if it couldn't be read
+	Layers int
+	// SharedLayers counts the layers other images use too
+	SharedLayers int
+	Labels       map[string]string
 }
 
 type ContainerInfo struct {
@@ -75,45 +181,101 @@ type ContainerInfo struct {
 	Image     string
 	CreatedAt time.Time
 	Status    string
+	SandboxID string
+	Labels    map[string]string
 }
 
 type TaskInfo struct {
-	ID     string
-	PID    uint32
-	Status string
+	ID       string
+	PID      uint32
+	Status   string
+	Orphaned bool
+	// Runtime is the runtime of the container, e.g. io.containerd.runc.v2;
+	// unknown for orphaned tasks
+	Runtime string
 }
 
 type SnapshotInfo struct {
-	Key    string
-	Parent string
-	Kind   string
+	Key         string
+	Parent      string
+	Kind        string
+	Snapshotter string
+	Labels      map[string]string
 }
 
 type ContentInfo struct {
-	Digest string
-	Size   int64
+	Digest     string
+	Size       int64
+	Refs       string
+	BuildCache bool
+	CreatedAt  time.Time
+	Labels     map[string]string
 }
 
+// Reference states of a content blob, from least to most protected.
+const (
+	contentOrphan     = "orphan"
+	contentReferenced = "referenced"
+	contentPinned     = "pinned"
+	// contentUnknown is shown when images or leases couldn't be walked
+	contentUnknown = "unknown"
+)
+
+// socketPath is the containerd socket lazyctr connects to.
+const socketPath = "/run/containerd/containerd.sock"
+
 func main() {
 	snapshotter := flag.String("snapshotter", "overlayfs", "Snapshotter to use (overlayfs, native, btrfs, zfs, etc.)")
+	allSnapshotters := flag.Bool("all-snapshotters", false, "Show snapshots of every available snapshotter in one view")
+	deleteCountdown := flag.Int("delete-countdown", 3, "Seconds before the namespace delete and rename buttons become active (0 disables)")
+	countdownAll := flag.Bool("countdown-all-deletes", false, "Apply the delete countdown to every delete confirmation")
+	pageSize := flag.Int("page-size", defaultPageSize, "Maximum number of items the items panel renders at once")
 	flag.Parse()
 
-	client, err := containerd.New("/run/containerd/containerd.sock")
+	if err := checkTerminal(); err != nil {
+		exitNoTerminal(err)
+	}
+
+	client, err := containerd.New(socketPath)
 	if err != nil {
-		log.Fatalf("Failed to connect to containerd: %v", err)
+		log.Fatalf("Failed to connect to containerd: %v%s", err, permissionHint(err))
 	}
 	defer client.Close()
 
 	app := &App{
-		tviewApp:        tview.NewApplication(),
-		client:          client,
-		currentResource: ResourceImages,
-		snapshotter:     *snapshotter,
+		tviewApp:           tview.NewApplication(),
+		client:             client,
+		currentResource:    ResourceImages,
+		marked:             make(map[string]bool),
+		imageVerifications: make(map[string]imageVerification),
+		snapshotter:        *snapshotter,
+		allSnapshotters:    *allSnapshotters,
+		deleteCountdown:    *deleteCountdown,
+		countdownAll:       *countdownAll,
+		pageSize:           max(*pageSize, 1),
+		config:             loadConfig(),
+	}
+
+	app.customColumns = parseCustomColumns(app.config)
+	if app.config.ConfirmDeleteAbove != "" {
+		app.deleteThreshold, _ = parseSize(app.config.ConfirmDeleteAbove)
 	}
+	app.refreshInterval = time.Duration(app.config.RefreshInterval) * time.Second
+	app.refreshReset = make(chan struct{}, 1)
 
 	if err := app.initUI(); err != nil {
-		log.Fatalf("Failed to initialize UI: %v", err)
+		log.Fatalf("Failed to initialize UI: %v%s", err, permissionHint(err))
+	}
+	app.focusStartPanel()
+
+	screen, err := newScreen()
+	if err != nil {
+		client.Close()
+		exitNoTerminal(err)
 	}
+	app.tviewApp.SetScreen(screen)
+
+	app.startAutoRefresh()
 
 	if err := app.tviewApp.Run(); err != nil {
 		log.Fatalf("Error running application: %v", err)
@@ -140,8 +302,8 @@ func (app *App) initUI() error {
 		SetTitleAlign(tview.AlignLeft)
 
 	// Add all resource types
-	resources := []ResourceType{ResourceImages, ResourceContainers, ResourceTasks, ResourceSnapshots, ResourceContent}
-	for _, res := range resources {
+	app.shownResources = allResources
+	for _, res := range allResources {
 		resType := res // capture for closure
 		app.resourceList.AddItem(resType.String(), "", 0, nil)
 	}
@@ -156,9 +318,13 @@ func (app *App) initUI() error {
 		SetTitle(" Items ").
 		SetTitleAlign(tview.AlignLeft)
 
+	app.itemTable.SetSelectedFunc(func(row, column int) {
+		app.showItemDetails()
+	})
+
 	// Create search input field
 	app.searchInput = tview.NewInputField().
-		SetLabel("Search: ").
+		SetLabel(app.searchLabel("Search: ")).
 		SetFieldWidth(50).
 		SetDoneFunc(func(key tcell.Key) {
 			if key == tcell.KeyEnter {
@@ -168,8 +334,18 @@ func (app *App) initUI() error {
 			}
 		})
 
+	// Ctrl-T switches case sensitivity while typing
+	app.searchInput.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlT {
+			app.toggleSearchCase()
+			return nil
+		}
+		return event
+	})
+
 	app.searchInput.SetChangedFunc(func(text string) {
 		app.searchQuery = text
+		app.pageStart = 0
 		app.filterItems()
 	})
 
@@ -179,27 +355,45 @@ func (app *App) initUI() error {
 		SetText("[yellow]Loading...[white]")
 	app.statusBar.SetBorder(false)
 
-	// Create help text
+	// Create help text, kept in sync with the focused panel
 	app.helpText = tview.NewTextView().
-		SetDynamicColors(true).
-		SetText("[yellow]q[white]:Quit [yellow]d[white]:Delete [yellow]D[white]:Delete NS [yellow]a[white]:Delete All [yellow]t[white]:Tag [yellow]/[white]:Search [yellow]1-5[white]:Jump [yellow]?[white]:Help")
+		SetDynamicColors(true)
 	app.helpText.SetBorder(false)
+	app.hintText = tview.NewTextView().
+		SetDynamicColors(true)
+	app.hintText.SetBorder(false)
+
+	app.namespaceList.SetFocusFunc(app.updateHelpText)
+	app.resourceList.SetFocusFunc(app.updateHelpText)
+	app.itemTable.SetFocusFunc(app.updateHelpText)
+	app.updateHelpText()
+
+	// Detect available snapshotters before anything tries to use one
+	app.detectSnapshotters()
+	app.detectTransfer()
+	app.detectDataRoot()
 
 	// Load namespaces
 	if err := app.loadNamespaces(); err != nil {
 		return fmt.Errorf("failed to load namespaces: %w", err)
 	}
+	app.countResources()
 
 	// Set up namespace selection handler
-	app.namespaceList.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-		app.currentNamespace = mainText
-		app.loadItems()
-	})
+	app.namespaceList.SetChangedFunc(app.namespaceChanged)
 
 	// Set up resource selection handler
-	app.resourceList.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
-		app.currentResource = ResourceType(index)
-		app.loadItems()
+	app.resourceList.SetChangedFunc(app.resourceChanged)
+
+	// Arrows choose a namespace or resource type, Enter dives into its items
+	app.namespaceList.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		if mainText != app.currentNamespace {
+			app.namespaceChanged(index, mainText, secondaryText, shortcut)
+		}
+		app.tviewApp.SetFocus(app.itemTable)
+	})
+	app.resourceList.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		app.tviewApp.SetFocus(app.itemTable)
 	})
 
 	// Create three-panel layout
@@ -209,38 +403,50 @@ func (app *App) initUI() error {
 	middlePanel := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(app.resourceList, 0, 1, false)
 
-	rightPanel := tview.NewFlex().SetDirection(tview.FlexRow).
+	app.itemsPanel = tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(app.itemTable, 0, 1, false)
 
-	mainFlex := tview.NewFlex().
+	app.mainFlex = tview.NewFlex().
 		AddItem(leftPanel, 0, 1, true).
 		AddItem(middlePanel, 0, 1, false).
-		AddItem(rightPanel, 0, 3, false)
+		AddItem(app.itemsPanel, 0, app.config.ItemsPanelWeight, false)
 
 	bottomBar := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(app.statusBar, 1, 0, false).
-		AddItem(app.helpText, 1, 0, false)
+		AddItem(app.helpText, 1, 0, false).
+		AddItem(app.hintText, 1, 0, false)
 
 	layout := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(mainFlex, 0, 1, true).
-		AddItem(bottomBar, 2, 0, false)
+		AddItem(app.mainFlex, 0, 1, true).
+		AddItem(bottomBar, 3, 0, false)
 
 	// Create pages for modal dialogs
 	app.pages = tview.NewPages().
 		AddPage("main", layout, true, true)
+	app.panelFocus = app.namespaceList
+	app.pages.SetChangedFunc(app.trackPanelFocus)
 
 	// Set up keyboard shortcuts
 	app.pages.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		// Don't process shortcuts if an input field has focus
-		if app.searchInput.HasFocus() || (app.tagInput != nil && app.tagInput.HasFocus()) {
+		if app.inputHasFocus() {
+			return event
+		}
+
+		// Leave keys to dialogs that are open on top of the main page
+		if name, _ := app.pages.GetFrontPage(); name != "main" {
 			return event
 		}
 
+		if app.itemTable.HasFocus() && app.pageKey(event) {
+			return nil
+		}
+
 		switch event.Key() {
 		case tcell.KeyRune:
 			switch event.Rune() {
 			case 'q', 'Q':
-				app.tviewApp.Stop()
+				app.quit()
 				return nil
 			case 'd':
 				if app.itemTable.HasFocus() {
@@ -260,34 +466,252 @@ func (app *App) initUI() error {
 			case 't', 'T':
 				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
 					app.tagImage()
+				} else if app.itemTable.HasFocus() && app.currentResource == ResourceTasks {
+					app.showTaskTop()
+				}
+				return nil
+			case 'p':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.pullImage()
+				}
+				return nil
+			case 'N':
+				app.reloadNamespaces()
+				return nil
+			case 'b':
+				app.toggleRawSizes()
+				return nil
+			case 'm':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.bulkRetag()
+				}
+				return nil
+			case 'K':
+				if app.currentResource == ResourceSnapshots {
+					app.toggleShortSnapshotKeys()
+				}
+				return nil
+			case 'F':
+				app.toggleFollowTail()
+				return nil
+			case 'Z':
+				app.toggleSelectFirst()
+				return nil
+			case '+':
+				app.adjustRefreshInterval(1)
+				return nil
+			case '-':
+				app.adjustRefreshInterval(-1)
+				return nil
+			case 'u':
+				app.showDiskUsage()
+				return nil
+			case 'v':
+				if app.itemTable.HasFocus() {
+					app.toggleTreeView()
+				}
+				return nil
+			case '=':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.diffImages()
+				}
+				return nil
+			case 'S':
+				if app.currentResource == ResourceImages {
+					app.cycleImageSize()
+				}
+				return nil
+			case 'H':
+				app.showDeleteHistory()
+				return nil
+			case '!':
+				app.checkHealth()
+				return nil
+			case 'Y':
+				app.copyNamespaceSummary()
+				return nil
+			case 'W':
+				if app.currentNamespace != "" {
+					app.showDiskPaths()
+				}
+				return nil
+			case 'L':
+				if app.namespaceList.HasFocus() {
+					app.showNamespaceLabels()
+				} else if app.itemTable.HasFocus() && (app.currentResource == ResourceContainers || app.currentResource == ResourceTasks) {
+					app.showLogs()
+				}
+				return nil
+			case 'o':
+				if app.namespaceList.HasFocus() {
+					app.toggleNamespaceSort()
+				}
+				return nil
+			case 'M':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.openManifestInPager()
+				}
+				return nil
+			case 'V':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.verifyImages()
+				}
+				return nil
+			case 'y':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.copyLayerDigests()
+				}
+				return nil
+			case '&':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.confirmDeleteAliases()
+				}
+				return nil
+			case 'J':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.jumpToImageConfig()
+				}
+				return nil
+			case 'E':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.cycleImageAge()
+				}
+				return nil
+			case 'x':
+				if app.resourceList.HasFocus() {
+					app.toggleHideEmptyResources()
+				}
+				return nil
+			case 'O':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.toggleUnusedImages()
+				}
+				return nil
+			case 'U':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.showDuplicateImages()
+				}
+				return nil
+			case 'X':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.pruneExpiredImages()
+				}
+				return nil
+			case 'R':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceImages {
+					app.runContainer()
+				} else if app.itemTable.HasFocus() && (app.currentResource == ResourceContainers || app.currentResource == ResourceTasks) {
+					app.restartContainer()
+				} else if app.namespaceList.HasFocus() {
+					app.renameNamespace()
+				}
+				return nil
+			case 's':
+				if app.currentResource == ResourceSnapshots {
+					app.toggleAllSnapshotters()
+				}
+				return nil
+			case 'e':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceContent {
+					app.exportBlob()
+				}
+				return nil
+			case '|':
+				if app.itemTable.HasFocus() && app.currentNamespace != "" {
+					app.showColumnFilters()
+				}
+				return nil
+			case 'B':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceContent {
+					app.removeBlobLeases()
+				}
+				return nil
+			case 'c':
+				if app.itemTable.HasFocus() && app.currentResource == ResourceContent {
+					app.copyContent()
+				}
+				return nil
+			case 'f':
+				if app.currentResource == ResourceContent {
+					app.toggleFullDigests()
+				}
+				return nil
+			case 'P':
+				if !app.itemTable.HasFocus() {
+					return nil
+				}
+				if app.currentResource == ResourceImages {
+					app.pushImage()
+				} else if app.currentResource == ResourceSnapshots {
+					app.pruneSnapshots()
+				} else if app.currentResource == ResourceContent && app.currentNamespace == buildkitNamespace {
+					app.pruneBuildCache()
+				}
+				return nil
+			case 'C':
+				if app.currentResource == ResourceContainers {
+					app.toggleAgeColors()
+				}
+				return nil
+			case 'I':
+				if app.currentResource == ResourceContainers || app.currentResource == ResourceTasks {
+					app.cycleContainerIDs()
+				}
+				return nil
+			case ' ':
+				if app.itemTable.HasFocus() {
+					app.toggleMark()
+				}
+				return nil
+			case 'w':
+				if app.itemTable.HasFocus() && (app.currentResource == ResourceContainers || app.currentResource == ResourceTasks) {
+					app.watchItem()
 				}
 				return nil
 			case '/':
 				app.showSearch()
 				return nil
+			case '*':
+				app.showGlobalSearch()
+				return nil
 			case '?':
 				app.showHelp()
 				return nil
 			case '1':
-				app.resourceList.SetCurrentItem(0)
+				app.selectResource(ResourceImages)
 				app.tviewApp.SetFocus(app.resourceList)
 				return nil
 			case '2':
-				app.resourceList.SetCurrentItem(1)
+				app.selectResource(ResourceContainers)
 				app.tviewApp.SetFocus(app.resourceList)
 				return nil
 			case '3':
-				app.resourceList.SetCurrentItem(2)
+				app.selectResource(ResourceTasks)
 				app.tviewApp.SetFocus(app.resourceList)
 				return nil
 			case '4':
-				app.resourceList.SetCurrentItem(3)
+				app.selectResource(ResourceSnapshots)
 				app.tviewApp.SetFocus(app.resourceList)
 				return nil
 			case '5':
-				app.resourceList.SetCurrentItem(4)
+				app.selectResource(ResourceContent)
+				app.tviewApp.SetFocus(app.resourceList)
+				return nil
+			case '<':
+				app.resizeItemsPanel(-1)
+				return nil
+			case '>':
+				app.resizeItemsPanel(1)
+				return nil
+			case 'n':
+				app.tviewApp.SetFocus(app.namespaceList)
+				return nil
+			case 'r':
 				app.tviewApp.SetFocus(app.resourceList)
 				return nil
+			case 'i':
+				app.tviewApp.SetFocus(app.itemTable)
+				return nil
 			}
 		case tcell.KeyTab:
 			if app.namespaceList.HasFocus() {
@@ -298,6 +722,9 @@ func (app *App) initUI() error {
 				app.tviewApp.SetFocus(app.namespaceList)
 			}
 			return nil
+		case tcell.KeyCtrlR:
+			app.refreshItems()
+			return nil
 		case tcell.KeyBacktab:
 			if app.itemTable.HasFocus() {
 				app.tviewApp.SetFocus(app.resourceList)
@@ -316,11 +743,65 @@ func (app *App) initUI() error {
 		return event
 	})
 
+	// Remember the screen so actions can reach the terminal clipboard
+	app.tviewApp.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		app.screen = screen
+		return false
+	})
+
 	app.tviewApp.SetRoot(app.pages, true)
 
 	return nil
 }
 
+// resizeItemsPanel grows or shrinks the items panel relative to the
+// sidebars and remembers the new proportion for the next run.
+func (app *App) resizeItemsPanel(delta int) {
+	weight := min(max(app.config.ItemsPanelWeight+delta, minItemsPanelWeight), maxItemsPanelWeight)
+	if weight == app.config.ItemsPanelWeight {
+		return
+	}
+
+	app.config.ItemsPanelWeight = weight
+	app.mainFlex.ResizeItem(app.itemsPanel, 0, weight)
+
+	app.saveSetting("Panel split", fmt.Sprintf("1:1:%d", weight))
+}
+
+// updateHelpText shows the shortcuts that apply to the focused panel and,
+// for the items panel, to the current resource type.
+func (app *App) updateHelpText() {
+	keys := [][2]string{{"q", "Quit"}}
+
+	switch {
+	case app.namespaceList.HasFocus():
+		keys = append(keys, [2]string{"D", "Delete NS"}, [2]string{"R", "Rename NS"}, [2]string{"L", "Labels"}, [2]string{"N", "Reload"})
+	case app.resourceList.HasFocus():
+		keys = append(keys, [2]string{"x", "Hide Empty"})
+	case app.itemTable.HasFocus():
+		keys = append(keys, [2]string{"d", "Delete"}, [2]string{"a", "Delete All"}, [2]string{"Space", "Mark"}, [2]string{"Enter", "Details"})
+		if treeLayouts[app.currentResource] != nil {
+			keys = append(keys, [2]string{"v", "Tree/Flat"})
+		}
+	}
+
+	keys = append(keys, [2]string{"/", "Search"}, [2]string{"*", "Find All"}, [2]string{"1-5", "Jump"}, [2]string{"?", "Help"})
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("[yellow]%s[white]:%s", key[0], key[1])
+	}
+	app.helpText.SetText(strings.Join(parts, " "))
+
+	// The hint line lists what can be done with the items of the current
+	// resource type, also while choosing one in the resource panel
+	if app.itemTable.HasFocus() || app.resourceList.HasFocus() {
+		app.hintText.SetText(app.resourceHint(app.currentResource))
+	} else {
+		app.hintText.SetText("")
+	}
+}
+
 func (app *App) loadNamespaces() error {
 	ctx := context.Background()
 
@@ -330,197 +811,630 @@ func (app *App) loadNamespaces() error {
 		return fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
-	app.namespaceList.Clear()
-
-	for _, ns := range nsList {
-		app.namespaceList.AddItem(ns, "", 0, nil)
-	}
+	nsList = app.setNamespaces(nsList)
 
+	// Always start with a namespace selected so the items panel has content
 	if len(nsList) > 0 {
 		app.currentNamespace = nsList[0]
 		app.namespaceList.SetCurrentItem(0)
 		app.loadItems()
+	} else {
+		app.currentNamespace = ""
+		app.showNoNamespace()
+		return nil
 	}
 
 	app.updateStatus(fmt.Sprintf("Loaded %d namespaces", len(nsList)))
 	return nil
 }
 
+// showNoNamespace explains the empty items panel when containerd has no
+// namespace to show.
+func (app *App) showNoNamespace() {
+	app.allItems = nil
+	app.itemCache = nil
+	app.itemTable.Clear()
+	app.itemTable.SetCell(1, 0, tview.NewTableCell("No namespaces found. Namespaces appear once something is created in them,").
+		SetTextColor(tcell.ColorGray).
+		SetAlign(tview.AlignCenter))
+	app.itemTable.SetCell(2, 0, tview.NewTableCell("e.g. by pulling an image. Press N to reload the namespace list.").
+		SetTextColor(tcell.ColorGray).
+		SetAlign(tview.AlignCenter))
+	app.itemTable.Select(0, 0)
+	app.itemTable.SetSelectable(false, false)
+	app.itemTable.SetTitle(fmt.Sprintf(" %s ", app.currentResource))
+	app.updateStatus("[yellow]No namespaces found")
+}
+
+func (app *App) namespaceChanged(index int, mainText, secondaryText string, shortcut rune) {
+	app.currentNamespace = mainText
+	app.clearMarks()
+	app.searchQuery = ""
+	app.clearResourceCounts()
+	app.loadItems()
+	app.countResources()
+}
+
+func (app *App) resourceChanged(index int, mainText, secondaryText string, shortcut rune) {
+	app.currentResource = app.shownResources[index]
+	app.clearMarks()
+	app.searchQuery = ""
+	app.hideColumnFilters()
+	app.showSwitchPending()
+	app.loadItems()
+	app.updateHelpText()
+}
+
+// showSwitchPending draws the headers and title of the newly selected
+// resource type right away, before its items are loaded, so a switch shows
+// at once even when containerd is slow to answer.
+func (app *App) showSwitchPending() {
+	if app.currentNamespace == "" {
+		return
+	}
+
+	app.allItems = nil
+	app.itemCache = nil
+	app.renderedItems = nil
+	app.itemTable.Clear()
+	app.renderResourceTable()
+	app.itemTable.SetCell(1, 0, tview.NewTableCell("Loading…").
+		SetTextColor(tcell.ColorGray).
+		SetAlign(tview.AlignCenter))
+	app.itemTable.Select(0, 0)
+	app.itemTable.SetSelectable(false, false)
+	app.itemTable.SetTitle(fmt.Sprintf(" %s [%s] (loading…) ", app.currentResource, app.currentNamespace))
+	app.updateHelpText()
+	app.tviewApp.ForceDraw()
+}
+
+// reloadNamespaces refreshes the namespace list, keeping the current
+// namespace selected if it still exists.
+func (app *App) reloadNamespaces() {
+	nsList, err := app.client.NamespaceService().List(context.Background())
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to list namespaces: %v", err))
+		return
+	}
+
+	nsList = app.setNamespaces(nsList)
+	index := max(slices.Index(nsList, app.currentNamespace), 0)
+
+	switch {
+	case len(nsList) == 0:
+		app.currentNamespace = ""
+		app.clearMarks()
+		app.showNoNamespace()
+		return
+	case nsList[index] != app.currentNamespace:
+		app.namespaceChanged(index, nsList[index], "", 0)
+	default:
+		app.countResources()
+	}
+
+	app.updateStatus(fmt.Sprintf("Reloaded [green]%d[white] namespaces", len(nsList)))
+}
+
 func (app *App) loadItems() {
 	if app.currentNamespace == "" {
+		app.showNoNamespace()
 		return
 	}
 
 	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
 
-	app.allItems = make([]interface{}, 0)
+	// Let containerd narrow down the content walk when the search allows it
+	var items []interface{}
+	var err error
+	filters := app.contentWalkFilters()
+	switch {
+	case len(filters) > 0:
+		items, err = app.loadContent(ctx, filters...)
+	case app.currentResource == ResourceImages:
+		// Sizes are filled in by scanImageSizes once the table is shown
+		items, err = app.listImages(ctx)
+		if err == nil && app.unusedOnly {
+			app.usedImages, err = app.containerImages(ctx)
+		}
+	default:
+		items, err = app.fetchItems(ctx, app.currentResource)
+	}
+	app.contentFiltered = len(filters) > 0
+	app.pageStart = 0
+	if view := app.viewKey(filters); err == nil && view == app.loadedView {
+		// Reloading the same view, e.g. after a pull; show what changed
+		app.highlightChanges(app.allItems, items)
+	} else {
+		app.changedItems = nil
+		app.loadedView = ""
+		if err == nil {
+			app.loadedView = view
+		}
+	}
+	app.allItems = make([]interface{}, 0, len(items))
+	app.allItems = append(app.allItems, items...)
 	app.itemCache = make([]interface{}, 0)
 
-	var err error
-	switch app.currentResource {
+	if err != nil {
+		app.itemTable.Clear()
+		app.itemTable.SetCell(1, 0, tview.NewTableCell(tview.Escape(err.Error())).
+			SetTextColor(tcell.ColorRed).
+			SetAlign(tview.AlignCenter))
+		app.itemTable.Select(0, 0)
+		app.itemTable.SetSelectable(false, false)
+		app.itemTable.SetTitle(fmt.Sprintf(" %s [%s] ", app.currentResource, app.currentNamespace))
+		app.updateStatus(fmt.Sprintf("[red]Error loading %s: %v", app.currentResource, err))
+		if hint := permissionHint(err); hint != "" {
+			app.showError(fmt.Sprintf("Cannot load %s: %v%s", app.currentResource, err, hint))
+		}
+		return
+	}
+
+	if !app.contentFiltered {
+		app.searchQuery = ""
+		app.updateResourceCount(app.currentResource, len(items))
+	}
+	app.scanImageSizes()
+	app.filterItems()
+}
+
+// fetchItems loads all items of a resource type from containerd.
+func (app *App) fetchItems(ctx context.Context, resource ResourceType) ([]interface{}, error) {
+	switch resource {
 	case ResourceImages:
-		err = app.loadImages(ctx)
+		return app.loadImages(ctx)
 	case ResourceContainers:
-		err = app.loadContainers(ctx)
+		return app.loadContainers(ctx)
 	case ResourceTasks:
-		err = app.loadTasks(ctx)
+		return app.loadTasks(ctx)
 	case ResourceSnapshots:
-		err = app.loadSnapshots(ctx)
+		return app.loadSnapshots(ctx)
 	case ResourceContent:
-		err = app.loadContent(ctx)
+		return app.loadContent(ctx)
 	}
+	return nil, nil
+}
 
+func (app *App) loadImages(ctx context.Context) ([]interface{}, error) {
+	items, err := app.listImages(ctx)
 	if err != nil {
-		app.updateStatus(fmt.Sprintf("[red]Error loading %s: %v", app.currentResource, err))
-		return
+		return nil, err
 	}
 
-	app.searchQuery = ""
-	app.filterItems()
+	namespace, _ := namespaces.Namespace(ctx)
+	for _, item := range items {
+		if img := item.(ImageInfo); img.Sizing {
+			app.measureImage(ctx, namespace, img)
+		}
+	}
+
+	app.fillImageSizes(namespace, items)
+	return items, nil
 }
 
-func (app *App) loadImages(ctx context.Context) error {
+// listImages lists images without waiting for their sizes. Sizes measured
+// before are filled in from the cache; the others are marked as Sizing for
+// scanImageSizes to compute in the background.
+func (app *App) listImages(ctx context.Context) ([]interface{}, error) {
+	var items []interface{}
+
 	imageService := app.client.ImageService()
 	imageList, err := imageService.List(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	contentStore := app.client.ContentStore()
 
 	for _, img := range imageList {
-		size, err := app.calculateImageSize(ctx, img, contentStore)
-		if err != nil {
-			size = img.Target.Size
-		}
+		platform, foreign := imagePlatform(ctx, img, contentStore)
 
 		imgInfo := ImageInfo{
 			Name:      img.Name,
-			Size:      size,
 			CreatedAt: img.CreatedAt,
+			Target:    img.Target,
+			Platform:  platform,
+			Foreign:   foreign,
+			Sizing:    true,
+			Labels:    img.Labels,
 		}
-		app.allItems = append(app.allItems, imgInfo)
+		if expires, ok := imageExpiry(img.Labels); ok {
+			imgInfo.Expires = expires
+		}
+		items = append(items, imgInfo)
 	}
 
-	return nil
+	namespace, _ := namespaces.Namespace(ctx)
+	app.fillImageSizes(namespace, items)
+	return items, nil
 }
 
-func (app *App) loadContainers(ctx context.Context) error {
+func (app *App) loadContainers(ctx context.Context) ([]interface{}, error) {
+	var items []interface{}
+
 	containers, err := app.client.Containers(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, container := range containers {
-		info, err := container.Info(ctx)
+		containerInfo, err := app.containerInfo(ctx, container)
 		if err != nil {
 			continue
 		}
-
-		containerInfo := ContainerInfo{
-			ID:        container.ID(),
-			Image:     info.Image,
-			CreatedAt: info.CreatedAt,
-			Status:    "Stopped",
-		}
-
-		// Check if task exists (running)
-		task, err := container.Task(ctx, nil)
-		if err == nil {
-			status, _ := task.Status(ctx)
-			containerInfo.Status = string(status.Status)
-		}
-
-		app.allItems = append(app.allItems, containerInfo)
+		items = append(items, containerInfo)
 	}
 
-	return nil
+	return items, nil
 }
 
-func (app *App) loadTasks(ctx context.Context) error {
-	containers, err := app.client.Containers(ctx)
+// containerInfo loads the row of one container, with the status of its
+// task if it has one.
+func (app *App) containerInfo(ctx context.Context, container containerd.Container) (ContainerInfo, error) {
+	info, err := container.Info(ctx)
 	if err != nil {
-		return err
+		return ContainerInfo{}, err
 	}
 
-	for _, container := range containers {
-		task, err := container.Task(ctx, nil)
-		if err != nil {
-			continue // No task for this container
-		}
-
-		status, err := task.Status(ctx)
-		if err != nil {
-			continue
-		}
+	containerInfo := ContainerInfo{
+		ID:        container.ID(),
+		Image:     info.Image,
+		CreatedAt: info.CreatedAt,
+		Status:    "Stopped",
+		Labels:    info.Labels,
+	}
 
-		taskInfo := TaskInfo{
-			ID:     container.ID(),
-			PID:    task.Pid(),
-			Status: string(status.Status),
-		}
+	// CRI containers belong to the pod of their sandbox container
+	if metadata, err := decodeCRIMetadata(info); err == nil {
+		containerInfo.SandboxID = metadata.SandboxID
+	}
 
-		app.allItems = append(app.allItems, taskInfo)
+	// Check if task exists (running)
+	task, err := container.Task(ctx, nil)
+	if err == nil {
+		status, _ := task.Status(ctx)
+		containerInfo.Status = string(status.Status)
 	}
 
-	return nil
+	return containerInfo, nil
 }
 
-func (app *App) loadSnapshots(ctx context.Context) error {
-	snapshotter := app.client.SnapshotService(app.snapshotter)
+// taskInfo loads the row of the task of a container. It fails if the
+// container has no task.
+func (app *App) taskInfo(ctx context.Context, container containerd.Container) (TaskInfo, error) {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return TaskInfo{}, err
+	}
 
-	var snapshotList []SnapshotInfo
-	err := snapshotter.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
-		snapshotInfo := SnapshotInfo{
-			Key:    info.Name,
-			Parent: info.Parent,
-			Kind:   string(info.Kind),
+	status, err := task.Status(ctx)
+	if err != nil {
+		return TaskInfo{}, err
+	}
+
+	taskInfo := TaskInfo{
+		ID:     container.ID(),
+		PID:    task.Pid(),
+		Status: string(status.Status),
+	}
+
+	// The container list already fetched the metadata
+	if info, err := container.Info(ctx, containerd.WithoutRefreshedMetadata); err == nil {
+		taskInfo.Runtime = info.Runtime.Name
+	}
+
+	return taskInfo, nil
+}
+
+func (app *App) loadTasks(ctx context.Context) ([]interface{}, error) {
+	var items []interface{}
+
+	containers, err := app.client.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(containers))
+	for _, container := range containers {
+		known[container.ID()] = true
+
+		taskInfo, err := app.taskInfo(ctx, container)
+		if err != nil {
+			continue // No task for this container
+		}
+		items = append(items, taskInfo)
+	}
+
+	// Tasks left behind by a partially removed container can't be reached
+	// through the container list, so ask the task service for all of them.
+	resp, err := app.client.TaskService().List(ctx, &tasks.ListTasksRequest{})
+	if err != nil {
+		return items, nil
+	}
+
+	for _, process := range resp.Tasks {
+		if known[process.ID] {
+			continue
+		}
+
+		items = append(items, TaskInfo{
+			ID:       process.ID,
+			PID:      process.Pid,
+			Status:   strings.ToLower(process.Status.String()),
+			Orphaned: true,
+		})
+	}
+
+	return items, nil
+}
+
+func (app *App) loadSnapshots(ctx context.Context) ([]interface{}, error) {
+	if app.allSnapshotters && app.snapshotters != nil {
+		return app.loadAllSnapshots(ctx)
+	}
+
+	// SnapshotService never fails up front for an unknown plugin, only on
+	// first use with an unhelpful error, so check against what we detected.
+	if app.snapshotters != nil && !slices.Contains(app.snapshotters, app.snapshotter) {
+		available := "none"
+		if len(app.snapshotters) > 0 {
+			available = strings.Join(app.snapshotters, ", ")
+		}
+		return nil, fmt.Errorf("snapshotter %q is not available (available: %s); restart with --snapshotter <name>", app.snapshotter, available)
+	}
+
+	snapshotList, err := app.walkSnapshots(ctx, app.snapshotter)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	for _, snap := range snapshotList {
+		items = append(items, snap)
+	}
+
+	return items, nil
+}
+
+// loadAllSnapshots aggregates the snapshots of every available
+// snapshotter. Snapshotters that fail to walk are skipped unless all do.
+func (app *App) loadAllSnapshots(ctx context.Context) ([]interface{}, error) {
+	var items []interface{}
+	var lastErr error
+	loaded := 0
+
+	for _, name := range app.snapshotters {
+		snapshotList, err := app.walkSnapshots(ctx, name)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", name, err)
+			continue
+		}
+		loaded++
+
+		for _, snap := range snapshotList {
+			items = append(items, snap)
+		}
+	}
+
+	if loaded == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return items, nil
+}
+
+func (app *App) walkSnapshots(ctx context.Context, name string) ([]SnapshotInfo, error) {
+	snapshotter := app.client.SnapshotService(name)
+
+	var snapshotList []SnapshotInfo
+	err := snapshotter.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+		snapshotInfo := SnapshotInfo{
+			Key:         info.Name,
+			Parent:      info.Parent,
+			Kind:        string(info.Kind),
+			Snapshotter: name,
+			Labels:      info.Labels,
 		}
 		snapshotList = append(snapshotList, snapshotInfo)
 		return nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, snap := range snapshotList {
-		app.allItems = append(app.allItems, snap)
-	}
+	return snapshotList, nil
+}
 
-	return nil
+// contentDigestQuery matches searches that are plain digest fragments,
+// which the content store can filter on by itself.
+var contentDigestQuery = regexp.MustCompile(`^(sha256:)?[0-9a-f]+$`)
+
+// contentWalkFilters returns a server-side filter for the current search
+// in the Content view, or nil if the search has to be applied client-side.
+func (app *App) contentWalkFilters() []string {
+	query := app.searchQuery
+	if !app.config.CaseSensitiveSearch {
+		query = strings.ToLower(query)
+	}
+	if app.currentResource != ResourceContent || !contentDigestQuery.MatchString(query) {
+		return nil
+	}
+	return []string{fmt.Sprintf(`digest~="%s"`, query)}
 }
 
-func (app *App) loadContent(ctx context.Context) error {
+func (app *App) loadContent(ctx context.Context, filters ...string) ([]interface{}, error) {
 	contentStore := app.client.ContentStore()
+	namespace, _ := namespaces.Namespace(ctx)
+
+	// Digest searches reload on every change of the query, so they reuse
+	// the references of the last full load
+	known := app.namespaceContentRefs(ctx, namespace, len(filters) == 0)
 
+	// In the buildkit namespace, tell build cache apart from image content
+	buildkit := namespace == buildkitNamespace
+
+	var items []interface{}
 	var contentList []ContentInfo
 	err := contentStore.Walk(ctx, func(info content.Info) error {
+		_, gcRoot := info.Labels[gcRootLabel]
+
+		refs := contentOrphan
+		switch {
+		case gcRoot || known.pinned[info.Digest]:
+			refs = contentPinned
+		case known.err != nil:
+			// Without the image and lease walks, blobs are still listed
+			refs = contentUnknown
+		case known.images[info.Digest]:
+			refs = contentReferenced
+		}
+
 		contentInfo := ContentInfo{
-			Digest: info.Digest.String(),
-			Size:   info.Size,
+			Digest:     info.Digest.String(),
+			Size:       info.Size,
+			Refs:       refs,
+			BuildCache: buildkit && known.err == nil && !known.images[info.Digest] && isBuildCache(info.Labels, known.pinned[info.Digest]),
+			CreatedAt:  info.CreatedAt,
+			Labels:     info.Labels,
 		}
 		contentList = append(contentList, contentInfo)
 		return nil
-	})
+	}, filters...)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, c := range contentList {
-		app.allItems = append(app.allItems, c)
+		items = append(items, c)
 	}
 
-	return nil
+	return items, nil
+}
+
+// contentRefs is what keeps the blobs of a namespace referenced: images
+// and leases. err tells why they are unknown, if walking them failed.
+type contentRefs struct {
+	images   map[digest.Digest]bool
+	pinned   map[digest.Digest]bool
+	err      error
+	computed time.Time
+}
+
+// namespaceContentRefs returns the content references of a namespace.
+// With fresh unset a cached result is reused until it expires like the
+// content usage; deletes and pulls invalidate it early.
+func (app *App) namespaceContentRefs(ctx context.Context, namespace string, fresh bool) contentRefs {
+	app.usageMu.Lock()
+	cached, ok := app.refsCache[namespace]
+	app.usageMu.Unlock()
+	if !fresh && ok && time.Since(cached.computed) < contentUsageTTL {
+		return cached
+	}
+
+	refs := contentRefs{computed: time.Now()}
+	refs.images, refs.err = app.imageContentDigests(ctx)
+	if refs.err == nil {
+		refs.pinned, refs.err = app.pinnedContentDigests(ctx)
+	}
+
+	app.usageMu.Lock()
+	if app.refsCache == nil {
+		app.refsCache = make(map[string]contentRefs)
+	}
+	app.refsCache[namespace] = refs
+	app.usageMu.Unlock()
+
+	return refs
+}
+
+// imageContentDigests collects every blob reachable from the images of a
+// namespace: indexes, manifests, configs and layers. Children that were
+// never fetched, such as other platforms of an index, are skipped.
+func (app *App) imageContentDigests(ctx context.Context) (map[digest.Digest]bool, error) {
+	imageList, err := app.client.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contentStore := app.client.ContentStore()
+	refs := make(map[digest.Digest]bool)
+
+	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		refs[desc.Digest] = true
+		children, err := images.Children(ctx, contentStore, desc)
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return children, err
+	})
+
+	for _, img := range imageList {
+		if err := images.Walk(ctx, handler, img.Target); err != nil {
+			return nil, fmt.Errorf("%s: %w", img.Name, err)
+		}
+	}
+
+	return refs, nil
+}
+
+// pinnedContentDigests collects the blobs held by leases, which keep
+// content alive while it is being pulled or used by clients like buildkit.
+func (app *App) pinnedContentDigests(ctx context.Context) (map[digest.Digest]bool, error) {
+	leaseService := app.client.LeasesService()
+	leaseList, err := leaseService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[digest.Digest]bool)
+	for _, lease := range leaseList {
+		resources, err := leaseService.ListResources(ctx, lease)
+		if err != nil {
+			return nil, fmt.Errorf("lease %s: %w", lease.ID, err)
+		}
+		for _, resource := range resources {
+			if resource.Type == "content" {
+				pinned[digest.Digest(resource.ID)] = true
+			}
+		}
+	}
+
+	return pinned, nil
 }
 
-func (app *App) calculateImageSize(ctx context.Context, img images.Image, contentStore content.Store) (int64, error) {
+func (app *App) toggleAllSnapshotters() {
+	if app.snapshotters == nil {
+		app.updateStatus(fmt.Sprintf("[yellow]Cannot list snapshotters: %s", tview.Escape(app.introspectionNote())))
+		return
+	}
+
+	app.allSnapshotters = !app.allSnapshotters
+	app.loadItems()
+	app.countResources()
+}
+
+// detectSnapshotters records the snapshotter plugins that loaded
+// successfully on the daemon. If introspection fails the list stays nil,
+// snapshotter names are used unchecked and only the configured
+// snapshotter is shown.
+func (app *App) detectSnapshotters() {
+	snapshotters, ok := app.loadedPlugins("io.containerd.snapshotter.v1")
+	if !ok {
+		app.allSnapshotters = false
+		return
+	}
+
+	slices.Sort(snapshotters)
+	app.snapshotters = snapshotters
+}
+
+func (app *App) calculateImageSize(ctx context.Context, img images.Image, contentStore content.Store) (int64, []ocispec.Descriptor, error) {
 	var size int64
 
 	manifest, err := images.Manifest(ctx, contentStore, img.Target, nil)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	size += manifest.Config.Size
@@ -529,7 +1443,40 @@ func (app *App) calculateImageSize(ctx context.Context, img images.Image, conten
 		size += layer.Size
 	}
 
-	return size, nil
+	return size, manifest.Layers, nil
+}
+
+// imagePlatform describes the platforms an image provides and reports
+// whether none of them can run on this host without emulation.
+func imagePlatform(ctx context.Context, img images.Image, provider content.Provider) (string, bool) {
+	available, err := images.Platforms(ctx, provider, img.Target)
+	if err != nil {
+		return "-", false
+	}
+
+	// Attestation manifests in an index carry an unknown/unknown platform
+	available = slices.DeleteFunc(available, func(p ocispec.Platform) bool {
+		return p.OS == "unknown"
+	})
+	if len(available) == 0 {
+		return "-", false
+	}
+
+	host := platforms.Default()
+	label := platforms.Format(available[0])
+	foreign := true
+	for _, p := range available {
+		if host.Match(p) {
+			label = platforms.Format(p)
+			foreign = false
+			break
+		}
+	}
+
+	if len(available) > 1 {
+		label = fmt.Sprintf("%s +%d", label, len(available)-1)
+	}
+	return label, foreign
 }
 
 func (app *App) filterItems() {
@@ -537,35 +1484,56 @@ func (app *App) filterItems() {
 		app.itemCache = app.allItems
 	} else {
 		app.itemCache = make([]interface{}, 0)
-		query := strings.ToLower(app.searchQuery)
 
 		for _, item := range app.allItems {
-			var searchField string
-			switch v := item.(type) {
-			case ImageInfo:
-				searchField = v.Name
-			case ContainerInfo:
-				searchField = v.ID + " " + v.Image
-			case TaskInfo:
-				searchField = v.ID
-			case SnapshotInfo:
-				searchField = v.Key
-			case ContentInfo:
-				searchField = v.Digest
+			if app.matchesSearch(searchField(item), app.searchQuery) {
+				app.itemCache = append(app.itemCache, item)
 			}
+		}
+	}
 
-			if strings.Contains(strings.ToLower(searchField), query) {
-				app.itemCache = append(app.itemCache, item)
+	if app.unusedImageFilter() {
+		var unused []interface{}
+		for _, item := range app.itemCache {
+			if !app.usedImages[item.(ImageInfo).Name] {
+				unused = append(unused, item)
 			}
 		}
+		app.itemCache = unused
+	}
+	if age := app.imageAgeFilter(); age > 0 {
+		app.itemCache = filterImageAge(app.itemCache, age)
+	}
+	app.itemCache = app.filterColumnValues(app.itemCache)
+
+	app.treePrefixes = nil
+	if app.treeView() {
+		app.itemCache, app.treePrefixes = treeOrder(app.itemCache, treeLayouts[app.currentResource](app.itemCache))
 	}
 
 	app.renderItemTable()
 }
 
-func (app *App) renderItemTable() {
-	app.itemTable.Clear()
+// searchField returns the text of an item that search queries match.
+func searchField(item interface{}) string {
+	switch v := item.(type) {
+	case ImageInfo:
+		return v.Name
+	case ContainerInfo:
+		return v.ID + " " + v.Image
+	case TaskInfo:
+		return v.ID
+	case SnapshotInfo:
+		return v.Key
+	case ContentInfo:
+		return v.Digest
+	}
+	return ""
+}
 
+// renderResourceTable fills the table with the headers and rows of the
+// current resource type.
+func (app *App) renderResourceTable() {
 	switch app.currentResource {
 	case ResourceImages:
 		app.renderImagesTable()
@@ -578,30 +1546,209 @@ func (app *App) renderItemTable() {
 	case ResourceContent:
 		app.renderContentTable()
 	}
+	app.renderCustomColumns()
+}
+
+func (app *App) renderItemTable() {
+	// Keep the selected item selected when the same view is drawn again,
+	// e.g. by a refresh, unless it is gone or moved to another page
+	var selected string
+	if !app.config.SelectFirst && app.renderedView != "" && app.renderedView == app.loadedView {
+		if row, _ := app.itemTable.GetSelection(); row >= 1 && row <= len(app.renderedItems) {
+			selected = itemID(app.renderedItems[row-1])
+		}
+	}
+
+	app.itemTable.Clear()
+	app.clampPage()
+	app.renderResourceTable()
+
+	// Draw the hierarchy in the first column
+	if app.treePrefixes != nil {
+		for i := range app.pageItems() {
+			cell := app.itemTable.GetCell(i+1, 0)
+			cell.SetText(app.treePrefixes[app.pageStart+i] + cell.Text)
+		}
+	}
+
+	// Flag marked rows in the first column
+	for i, item := range app.pageItems() {
+		if app.marked[itemID(item)] {
+			cell := app.itemTable.GetCell(i+1, 0)
+			cell.SetText("● " + cell.Text).SetTextColor(tcell.ColorFuchsia)
+		}
+	}
+	app.paintHighlights()
 
 	if len(app.itemCache) > 0 {
-		app.itemTable.Select(1, 0)
+		row := 1
+		for i, item := range app.pageItems() {
+			if selected != "" && itemID(item) == selected {
+				row = i + 1
+				break
+			}
+		}
+		app.itemTable.Select(row, 0)
 		app.itemTable.SetSelectable(true, false)
 	} else {
-		app.itemTable.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("No %s found", strings.ToLower(app.currentResource.String()))).
-			SetTextColor(tcell.ColorGray).
+		// Tell a filtered out view apart from an empty resource
+		message := fmt.Sprintf("No %s found", strings.ToLower(app.currentResource.String()))
+		color := tcell.ColorGray
+		switch {
+		case app.searchQuery != "" && app.contentFiltered:
+			// containerd filtered the walk, so what is hidden is unknown
+			message = fmt.Sprintf("No matches for '%s'", tview.Escape(app.searchQuery))
+			color = tcell.ColorYellow
+		case app.unusedImageFilter() && app.imageAgeFilter() == 0 && app.searchQuery == "" && len(app.allItems) > 0:
+			message = fmt.Sprintf("Every image is used by a container (%d items hidden)", len(app.allItems))
+			color = tcell.ColorYellow
+		case app.imageAgeFilter() > 0 && app.searchQuery == "" && len(app.allItems) > 0:
+			message = fmt.Sprintf("No images older than %s (%d items hidden)", ageLabel(app.imageAgeFilter()), len(app.allItems))
+			color = tcell.ColorYellow
+		case app.searchQuery != "" && len(app.allItems) > 0:
+			message = fmt.Sprintf("No matches for '%s' (%d items hidden)", tview.Escape(app.searchQuery), len(app.allItems))
+			color = tcell.ColorYellow
+		case len(app.columnFilters) > 0 && len(app.allItems) > 0:
+			message = fmt.Sprintf("No matches for the column filters (%d items hidden)", len(app.allItems))
+			color = tcell.ColorYellow
+		}
+		app.itemTable.SetCell(1, 0, tview.NewTableCell(message).
+			SetTextColor(color).
 			SetAlign(tview.AlignCenter))
 		app.itemTable.Select(0, 0)
 		app.itemTable.SetSelectable(false, false)
 	}
 
+	app.renderedItems = app.pageItems()
+	app.renderedView = app.loadedView
+
 	titleSuffix := ""
 	if app.searchQuery != "" {
 		titleSuffix = fmt.Sprintf(" (filtered: %s)", app.searchQuery)
 	}
-	app.itemTable.SetTitle(fmt.Sprintf(" %s [%s]%s ", app.currentResource, app.currentNamespace, titleSuffix))
+	if app.unusedImageFilter() {
+		titleSuffix += " (unused only)"
+	}
+	if age := app.imageAgeFilter(); age > 0 {
+		titleSuffix += fmt.Sprintf(" (older than %s)", ageLabel(age))
+	}
+	if note := app.columnFilterNote(); note != "" {
+		titleSuffix += fmt.Sprintf(" (%s)", note)
+	}
+	app.itemTable.SetTitle(fmt.Sprintf(" %s [%s]%s%s ", app.currentResource, app.currentNamespace, titleSuffix, app.pageNote()))
+
+	markNote := ""
+	if marked := len(app.markedItems()); marked > 0 {
+		markNote = fmt.Sprintf(" | Marked: [fuchsia]%d[white]", marked)
+	}
+
+	if app.currentResource == ResourceImages && len(app.allItems) > 0 {
+		computing := ""
+		if app.imageSizesPending {
+			computing = ", computing…"
+		}
+		markNote += fmt.Sprintf(" | Layers: [green]%s[white] (deduplicated%s)", formatSize(app.imageLayersTotal), computing)
+	}
+
+	// Only worth showing on hosts that mix runtimes
+	if app.currentResource == ResourceTasks {
+		if summary, runtimes := runtimeCounts(app.allItems); runtimes > 1 {
+			markNote += fmt.Sprintf(" | Runtimes: [teal]%s[white]", summary)
+		}
+	}
+
+	if app.currentResource == ResourceContent {
+		app.usageMu.Lock()
+		refs := app.refsCache[app.currentNamespace]
+		app.usageMu.Unlock()
+		if refs.err != nil {
+			markNote += fmt.Sprintf(" | [yellow]References unknown:[white] %s", tview.Escape(refs.err.Error()))
+		}
+	}
+
+	if app.currentResource == ResourceContent && app.currentNamespace == buildkitNamespace {
+		cache, size := buildCacheItems(app.allItems)
+		markNote += fmt.Sprintf(" | Prunable cache: [yellow]%d[white] (%s)", len(cache), formatSize(size))
+	}
+
+	if interval := app.currentRefreshInterval(); interval > 0 {
+		markNote += fmt.Sprintf(" | Refresh: [green]%s[white]", interval)
+	}
+	if app.followTail {
+		markNote += " | [green]Following[white]"
+	}
+	markNote += app.freeSpaceNote()
 
-	app.updateStatus(fmt.Sprintf("Namespace: [cyan]%s[white] | Resource: [yellow]%s[white] | Count: [green]%d[white]/%d",
-		app.currentNamespace, app.currentResource, len(app.itemCache), len(app.allItems)))
+	app.updateStatus(fmt.Sprintf("Namespace: [cyan]%s[white] | Resource: [yellow]%s[white] | Count: [green]%d[white]/%d%s",
+		app.currentNamespace, app.currentResource, len(app.itemCache), len(app.allItems), markNote))
+}
+
+// itemID returns the identifier an item is tracked by across reloads.
+func itemID(item interface{}) string {
+	switch v := item.(type) {
+	case ImageInfo:
+		return v.Name
+	case ContainerInfo:
+		return v.ID
+	case TaskInfo:
+		return v.ID
+	case SnapshotInfo:
+		return v.Snapshotter + "/" + v.Key
+	case ContentInfo:
+		return v.Digest
+	}
+	return ""
+}
+
+// containerIDOf returns the container an item belongs to, if any.
+func containerIDOf(item interface{}) string {
+	switch v := item.(type) {
+	case ContainerInfo:
+		return v.ID
+	case TaskInfo:
+		return v.ID
+	}
+	return ""
+}
+
+func (app *App) toggleMark() {
+	index := app.selectedIndex()
+	if index < 0 {
+		return
+	}
+	item := app.itemCache[index]
+
+	id := itemID(item)
+	if app.marked[id] {
+		delete(app.marked, id)
+	} else {
+		app.marked[id] = true
+	}
+
+	app.renderItemTable()
+
+	// Advance so several rows can be marked by repeatedly pressing Space
+	app.selectIndex(min(index+1, len(app.itemCache)-1))
+}
+
+func (app *App) clearMarks() {
+	app.marked = make(map[string]bool)
+}
+
+// markedItems returns the marked items that are still present in the
+// current view, in table order.
+func (app *App) markedItems() []interface{} {
+	var items []interface{}
+	for _, item := range app.allItems {
+		if app.marked[itemID(item)] {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 func (app *App) renderImagesTable() {
-	headers := []string{"Name", "Size", "Created"}
+	headers := []string{"Name", imageSizeHeaders[app.config.ImageSize], "Platform", "Created", "Expiry"}
 	for i, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorYellow).
@@ -611,13 +1758,44 @@ func (app *App) renderImagesTable() {
 		app.itemTable.SetCell(0, i, cell)
 	}
 
-	for i, item := range app.itemCache {
+	// Flag tags that share their content with other tags
+	duplicates := duplicateCounts(duplicateImages(app.allItems))
+
+	for i, item := range app.pageItems() {
 		img := item.(ImageInfo)
 		row := i + 1
 
-		app.itemTable.SetCell(row, 0, tview.NewTableCell(img.Name).SetTextColor(tcell.ColorWhite))
-		app.itemTable.SetCell(row, 1, tview.NewTableCell(formatSize(img.Size)).SetTextColor(tcell.ColorGreen))
-		app.itemTable.SetCell(row, 2, tview.NewTableCell(img.CreatedAt.Format("2006-01-02 15:04")).SetTextColor(tcell.ColorTeal))
+		name := img.Name
+		if others := duplicates[img.Name]; others > 0 {
+			name += fmt.Sprintf(" [gray](+%d same content)[-]", others)
+		}
+		if result, ok := app.imageVerifications[verificationKey(app.currentNamespace, img)]; ok {
+			if result.complete() {
+				name += " [green]✓[-]"
+			} else {
+				name += " [red]✗ incomplete[-]"
+			}
+		}
+		app.itemTable.SetCell(row, 0, tview.NewTableCell(name).SetTextColor(tcell.ColorWhite))
+		if img.Sizing || (app.config.ImageSize == imageSizeUnique && app.imageSizesPending) {
+			app.itemTable.SetCell(row, 1, tview.NewTableCell("computing…").SetTextColor(tcell.ColorGray))
+		} else if img.Layers == 0 {
+			// Artifacts, attestations and the like; tiny, but not a failed calculation
+			app.itemTable.SetCell(row, 1, tview.NewTableCell(app.sizeText(app.imageSize(img))+" (no layers)").SetTextColor(tcell.ColorGray))
+		} else {
+			app.itemTable.SetCell(row, 1, tview.NewTableCell(app.sizeText(app.imageSize(img))).SetTextColor(tcell.ColorGreen))
+		}
+		if img.Foreign {
+			app.itemTable.SetCell(row, 2, tview.NewTableCell("⚠ "+img.Platform).SetTextColor(tcell.ColorRed))
+		} else {
+			app.itemTable.SetCell(row, 2, tview.NewTableCell(img.Platform).SetTextColor(tcell.ColorTeal))
+		}
+		app.itemTable.SetCell(row, 3, tview.NewTableCell(app.formatTime(img.CreatedAt)).SetTextColor(tcell.ColorTeal))
+
+		if !img.Expires.IsZero() {
+			text, color := expiryText(img.Expires)
+			app.itemTable.SetCell(row, 4, tview.NewTableCell("⏱ "+text).SetTextColor(color))
+		}
 	}
 }
 
@@ -632,11 +1810,11 @@ func (app *App) renderContainersTable() {
 		app.itemTable.SetCell(0, i, cell)
 	}
 
-	for i, item := range app.itemCache {
+	for i, item := range app.pageItems() {
 		container := item.(ContainerInfo)
 		row := i + 1
 
-		app.itemTable.SetCell(row, 0, tview.NewTableCell(container.ID).SetTextColor(tcell.ColorWhite))
+		app.itemTable.SetCell(row, 0, tview.NewTableCell(app.containerIDText(container.ID)).SetTextColor(tcell.ColorWhite))
 		app.itemTable.SetCell(row, 1, tview.NewTableCell(container.Image).SetTextColor(tcell.ColorTeal))
 
 		statusColor := tcell.ColorGray
@@ -644,12 +1822,62 @@ func (app *App) renderContainersTable() {
 			statusColor = tcell.ColorGreen
 		}
 		app.itemTable.SetCell(row, 2, tview.NewTableCell(container.Status).SetTextColor(statusColor))
-		app.itemTable.SetCell(row, 3, tview.NewTableCell(container.CreatedAt.Format("2006-01-02 15:04")).SetTextColor(tcell.ColorTeal))
+
+		createdColor := tcell.ColorTeal
+		if app.config.AgeColors {
+			createdColor = ageColor(time.Since(container.CreatedAt))
+		}
+		app.itemTable.SetCell(row, 3, tview.NewTableCell(app.formatTime(container.CreatedAt)).SetTextColor(createdColor))
 	}
 }
 
+// ageColor fades from bright green for brand new items to gray for items
+// older than a day.
+func ageColor(age time.Duration) tcell.Color {
+	switch {
+	case age < time.Minute:
+		return tcell.ColorLime
+	case age < 10*time.Minute:
+		return tcell.ColorGreen
+	case age < time.Hour:
+		return tcell.ColorOlive
+	case age < 24*time.Hour:
+		return tcell.ColorTeal
+	default:
+		return tcell.ColorGray
+	}
+}
+
+// toggleAgeColors switches age coloring of the Created column on or off
+// and remembers the choice for the next run.
+func (app *App) toggleAgeColors() {
+	app.config.AgeColors = !app.config.AgeColors
+
+	index := app.selectedIndex()
+	app.renderItemTable()
+	app.selectIndex(index)
+
+	state := "off"
+	if app.config.AgeColors {
+		state = "on"
+	}
+	app.saveSetting("Age colors", state)
+}
+
+// toggleSelectFirst switches between keeping the selected item across
+// refreshes and going back to the first row on every refresh.
+func (app *App) toggleSelectFirst() {
+	app.config.SelectFirst = !app.config.SelectFirst
+
+	state := "keep the selected item"
+	if app.config.SelectFirst {
+		state = "select the first row"
+	}
+	app.saveSetting("On refresh", state)
+}
+
 func (app *App) renderTasksTable() {
-	headers := []string{"Container ID", "PID", "Status"}
+	headers := []string{"Container ID", "PID", "Status", "Runtime"}
 	for i, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorYellow).
@@ -659,18 +1887,78 @@ func (app *App) renderTasksTable() {
 		app.itemTable.SetCell(0, i, cell)
 	}
 
-	for i, item := range app.itemCache {
+	for i, item := range app.pageItems() {
 		task := item.(TaskInfo)
 		row := i + 1
 
-		app.itemTable.SetCell(row, 0, tview.NewTableCell(task.ID).SetTextColor(tcell.ColorWhite))
+		label, color := taskStatusStyle(task.Status)
+		if task.Orphaned {
+			label, color = task.Status+", orphaned (no container)", tcell.ColorRed
+		}
+		app.itemTable.SetCell(row, 0, tview.NewTableCell(app.containerIDText(task.ID)).SetTextColor(tcell.ColorWhite))
 		app.itemTable.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d", task.PID)).SetTextColor(tcell.ColorGreen))
-		app.itemTable.SetCell(row, 2, tview.NewTableCell(task.Status).SetTextColor(tcell.ColorTeal))
+		app.itemTable.SetCell(row, 2, tview.NewTableCell(label).SetTextColor(color))
+		app.itemTable.SetCell(row, 3, tview.NewTableCell(runtimeLabel(task.Runtime)).SetTextColor(tcell.ColorTeal))
+	}
+}
+
+// runtimeLabel shortens a runtime name for display, e.g.
+// io.containerd.kata.v2 to kata.v2.
+func runtimeLabel(runtime string) string {
+	if runtime == "" {
+		return "-"
+	}
+	return strings.TrimPrefix(runtime, "io.containerd.")
+}
+
+// runtimeCounts summarizes how many tasks of items run on each runtime,
+// e.g. "runc.v2 12, kata.v2 3", most used first.
+func runtimeCounts(items []interface{}) (string, int) {
+	counts := make(map[string]int)
+	for _, item := range items {
+		if task, ok := item.(TaskInfo); ok && task.Runtime != "" {
+			counts[runtimeLabel(task.Runtime)]++
+		}
+	}
+
+	names := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(names, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, counts[name])
+	}
+	return strings.Join(parts, ", "), len(names)
+}
+
+// taskStatusStyle returns the display label and color for a task status.
+func taskStatusStyle(status string) (string, tcell.Color) {
+	switch containerd.ProcessStatus(status) {
+	case containerd.Running:
+		return status, tcell.ColorGreen
+	case containerd.Created:
+		return "created (not started)", tcell.ColorYellow
+	case containerd.Paused, containerd.Pausing:
+		return status, tcell.ColorDodgerBlue
+	case containerd.Stopped:
+		return "stopped (exited)", tcell.ColorGray
+	case containerd.Unknown:
+		return "unknown (runtime unreachable)", tcell.ColorRed
+	default:
+		return status, tcell.ColorTeal
 	}
 }
 
 func (app *App) renderSnapshotsTable() {
 	headers := []string{"Key", "Parent", "Kind"}
+	if app.allSnapshotters {
+		headers = append(headers, "Snapshotter")
+	}
 	for i, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorYellow).
@@ -680,23 +1968,31 @@ func (app *App) renderSnapshotsTable() {
 		app.itemTable.SetCell(0, i, cell)
 	}
 
-	for i, item := range app.itemCache {
+	for i, item := range app.pageItems() {
 		snapshot := item.(SnapshotInfo)
 		row := i + 1
 
-		app.itemTable.SetCell(row, 0, tview.NewTableCell(snapshot.Key).SetTextColor(tcell.ColorWhite))
+		app.itemTable.SetCell(row, 0, tview.NewTableCell(app.snapshotKeyText(snapshot.Key)).SetTextColor(tcell.ColorWhite))
 
-		parent := snapshot.Parent
+		parent := app.snapshotKeyText(snapshot.Parent)
 		if parent == "" {
 			parent = "-"
 		}
 		app.itemTable.SetCell(row, 1, tview.NewTableCell(parent).SetTextColor(tcell.ColorTeal))
 		app.itemTable.SetCell(row, 2, tview.NewTableCell(snapshot.Kind).SetTextColor(tcell.ColorGreen))
+		if app.allSnapshotters {
+			app.itemTable.SetCell(row, 3, tview.NewTableCell(snapshot.Snapshotter).SetTextColor(tcell.ColorTeal))
+		}
 	}
 }
 
 func (app *App) renderContentTable() {
-	headers := []string{"Digest", "Size"}
+	buildkit := app.currentNamespace == buildkitNamespace
+
+	headers := []string{"Digest", "Size", "Refs", "Created"}
+	if buildkit {
+		headers = append(headers, "Usage")
+	}
 	for i, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorYellow).
@@ -706,22 +2002,66 @@ func (app *App) renderContentTable() {
 		app.itemTable.SetCell(0, i, cell)
 	}
 
-	for i, item := range app.itemCache {
+	for i, item := range app.pageItems() {
 		c := item.(ContentInfo)
 		row := i + 1
 
-		// Truncate digest for display
+		// Truncate digest for display unless full digests are asked for
 		digest := c.Digest
-		if len(digest) > 60 {
+		if !app.fullDigests && len(digest) > 60 {
 			digest = digest[:60] + "..."
 		}
 		app.itemTable.SetCell(row, 0, tview.NewTableCell(digest).SetTextColor(tcell.ColorWhite))
-		app.itemTable.SetCell(row, 1, tview.NewTableCell(formatSize(c.Size)).SetTextColor(tcell.ColorGreen))
+		app.itemTable.SetCell(row, 1, tview.NewTableCell(app.sizeText(c.Size)).SetTextColor(tcell.ColorGreen))
+
+		refsColor := tcell.ColorYellow
+		switch c.Refs {
+		case contentOrphan:
+			refsColor = tcell.ColorGreen
+		case contentPinned:
+			refsColor = tcell.ColorRed
+		case contentUnknown:
+			refsColor = tcell.ColorGray
+		}
+		app.itemTable.SetCell(row, 2, tview.NewTableCell(c.Refs).SetTextColor(refsColor))
+
+		app.itemTable.SetCell(row, 3, tview.NewTableCell(app.formatTime(c.CreatedAt)).SetTextColor(tcell.ColorTeal))
+
+		if buildkit {
+			switch {
+			case c.BuildCache:
+				app.itemTable.SetCell(row, 4, tview.NewTableCell("build cache").SetTextColor(tcell.ColorYellow))
+			case c.Refs == contentOrphan:
+				app.itemTable.SetCell(row, 4, tview.NewTableCell("-").SetTextColor(tcell.ColorGray))
+			default:
+				app.itemTable.SetCell(row, 4, tview.NewTableCell("image").SetTextColor(tcell.ColorGreen))
+			}
+		}
+	}
+}
+
+// toggleFullDigests switches the Content view between truncated and full
+// digests, e.g. for wide terminals.
+func (app *App) toggleFullDigests() {
+	app.fullDigests = !app.fullDigests
+
+	index := app.selectedIndex()
+	app.renderItemTable()
+	app.selectIndex(index)
+
+	if app.fullDigests {
+		app.updateStatus("Digests: [green]full[white]")
+		return
 	}
+	app.updateStatus("Digests: [green]truncated[white]")
 }
 
 func (app *App) showSearch() {
 	app.searchInput.SetText("")
+	if app.contentFiltered {
+		// Start the new search from the whole content store
+		app.loadItems()
+	}
 
 	modal := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(nil, 0, 1, false).
@@ -738,23 +2078,36 @@ func (app *App) showSearch() {
 func (app *App) closeSearchBox() {
 	app.pages.RemovePage("search")
 	app.tviewApp.SetFocus(app.itemTable)
+
+	// Reload digest searches through the content store's filter, so later
+	// reloads, e.g. after deletes, only walk the matching blobs
+	if len(app.contentWalkFilters()) > 0 {
+		app.loadItems()
+	}
 }
 
 func (app *App) hideSearch() {
 	app.searchQuery = ""
 	app.searchInput.SetText("")
-	app.filterItems()
-	app.pages.RemovePage("search")
-	app.tviewApp.SetFocus(app.itemTable)
+	if app.contentFiltered {
+		app.loadItems()
+	} else {
+		app.filterItems()
+	}
+	// Esc on the main page clears the filter of a closed search box
+	// without moving the focus
+	if app.pages.HasPage("search") {
+		app.closeDialog("search")
+	}
 }
 
 func (app *App) deleteSelectedItem() {
-	row, _ := app.itemTable.GetSelection()
-	if row <= 0 || row > len(app.itemCache) {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("delete")
 		return
 	}
 
-	item := app.itemCache[row-1]
 	var itemName string
 
 	switch v := item.(type) {
@@ -770,25 +2123,84 @@ func (app *App) deleteSelectedItem() {
 		itemName = v.Digest
 	}
 
+	namespace := app.currentNamespace
+	resource := app.currentResource
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	// Run the blocking operation in a goroutine to prevent UI freeze,
+	// snapshot sizes are asked from the snapshotter
+	go func() {
+		size := app.itemSize(ctx, item)
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			// The view changed meanwhile, the item is no longer shown
+			if app.currentNamespace != namespace || app.currentResource != resource {
+				return
+			}
+			app.confirmDelete(item, itemName, size)
+		})
+	}()
+}
+
+// confirmDelete asks before deleting an item of the current view, unless
+// confirmation was turned off or the item is below the size threshold.
+func (app *App) confirmDelete(item interface{}, itemName string, size int64) {
+	if task, ok := item.(TaskInfo); ok && task.Orphaned && orphanIsLive(task) {
+		app.confirmKillOrphanedTask(task)
+		return
+	}
+
+	if app.skipDeleteConfirm {
+		app.performDelete(item, size)
+		return
+	}
+
+	sizeNote := ""
+	if size > 0 {
+		sizeNote = fmt.Sprintf("\nSize: %s", app.sizeText(size))
+	}
+
+	// Small items below the configured threshold are deleted right away
+	if !app.needsDeleteConfirm(size) {
+		app.performDelete(item, size)
+		return
+	}
+
+	note := "This action cannot be undone!"
+	if img, ok := item.(ImageInfo); ok {
+		note = app.imageDeleteNote(img)
+	}
+
+	stopCountdown := func() {}
+	buttons := []string{"Delete", "Delete, don't ask again", "Cancel"}
 	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Delete %s?\n\n%s\n\nThis action cannot be undone!", app.currentResource, itemName)).
-		AddButtons([]string{"Delete", "Cancel"}).
+		SetText(fmt.Sprintf("Delete %s?\n\n%s%s\n\n%s", app.currentResource, itemName, sizeNote, note)).
+		AddButtons(buttons).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			if buttonLabel == "Delete" {
-				app.performDelete(item)
+			stopCountdown()
+			app.closeDialog("confirm")
+			switch buttonLabel {
+			case "Delete, don't ask again":
+				// Only single-item deletes skip confirmation from now on
+				app.skipDeleteConfirm = true
+				fallthrough
+			case "Delete":
+				app.performDelete(item, size)
 			}
-			app.pages.RemovePage("confirm")
-			app.tviewApp.SetFocus(app.itemTable)
 		})
 
 	modal.SetBorder(true).SetTitle(" Confirm Delete ")
 	modal.SetBackgroundColor(tcell.ColorDefault)
 
 	app.pages.AddPage("confirm", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, buttons, app.deleteCountdown)
+	}
 }
 
 func (app *App) deleteAllItems() {
 	if len(app.itemCache) == 0 {
+		app.reportNothingSelected("delete")
 		return
 	}
 
@@ -797,150 +2209,447 @@ func (app *App) deleteAllItems() {
 		filterNote = fmt.Sprintf("\n(Filtered results: %d of %d)", len(app.itemCache), len(app.allItems))
 	}
 
+	// Delete exactly what was confirmed, even if auto-refresh reloads the
+	// view while the dialog is open
+	targets := make([]deleteTarget, len(app.itemCache))
+	for i, item := range app.itemCache {
+		targets[i] = deleteTarget{namespace: app.currentNamespace, item: item}
+	}
+	app.confirmDeleteAll(targets, filterNote)
+}
+
+// maxListedNamespaces caps the namespaces a Delete All confirmation lists,
+// so a long list still fits the dialog. Each one is acknowledged anyway.
+const maxListedNamespaces = 10
+
+// confirmDeleteAll asks before deleting targets, grouped by namespace.
+// Targets in several namespaces are refused unless the config allows it,
+// and then every namespace has to be acknowledged on its own.
+func (app *App) confirmDeleteAll(targets []deleteTarget, filterNote string) {
+	names, counts := targetNamespaces(targets)
+	if len(names) > 1 && !app.config.CrossNamespaceDeleteAll {
+		app.showError(fmt.Sprintf("Refusing to delete %d %s: %v.", len(targets), app.currentResource, errCrossNamespaceDelete))
+		return
+	}
+
+	where := fmt.Sprintf("in namespace '%s'", names[0])
+	if len(names) > 1 {
+		var list strings.Builder
+		for i, name := range names {
+			if i == maxListedNamespaces {
+				fmt.Fprintf(&list, "\n… and %d more namespaces", len(names)-i)
+				break
+			}
+			fmt.Fprintf(&list, "\n%s: %d", name, counts[name])
+		}
+		where = fmt.Sprintf("in %d namespaces?%s\n\nEach namespace is confirmed separately next", len(names), list.String())
+	}
+
+	stopCountdown := func() {}
 	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Delete ALL %s in namespace '%s'?%s\n\nThis will delete %d items!\nThis action cannot be undone!",
-			app.currentResource, app.currentNamespace, filterNote, len(app.itemCache))).
+		SetText(fmt.Sprintf("Delete ALL %s %s?%s\n\nThis will delete %d items!\nThis action cannot be undone!",
+			app.currentResource, where, filterNote, len(targets))).
 		AddButtons([]string{"Delete All", "Cancel"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-all")
 			if buttonLabel == "Delete All" {
-				app.performDeleteAll()
+				app.acknowledgeNamespaces(names, counts, 0, func() {
+					app.performDeleteAll(targets)
+				})
 			}
-			app.pages.RemovePage("confirm-all")
-			app.tviewApp.SetFocus(app.itemTable)
 		})
 
 	modal.SetBorder(true).SetTitle(" ⚠ Confirm Delete All ")
 	modal.SetBackgroundColor(tcell.ColorDefault)
 
 	app.pages.AddPage("confirm-all", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, []string{"Delete All", "Cancel"}, app.deleteCountdown)
+	}
+}
+
+// acknowledgeNamespaces asks about each namespace of a cross-namespace
+// Delete All in turn, from names[index] on, and runs done once all of
+// them are acknowledged. A single namespace needs no further question.
+func (app *App) acknowledgeNamespaces(names []string, counts map[string]int, index int, done func()) {
+	if len(names) < 2 || index == len(names) {
+		done()
+		return
+	}
+
+	name := names[index]
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete %d %s in namespace '%s'?\n\nNamespace %d of %d; Cancel deletes nothing in any namespace.",
+			counts[name], app.currentResource, name, index+1, len(names))).
+		AddButtons([]string{"Delete in " + name, "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			app.closeDialog("confirm-namespace")
+			if buttonIndex == 0 {
+				app.acknowledgeNamespaces(names, counts, index+1, done)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Namespace ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+	app.pages.AddPage("confirm-namespace", modal, true, true)
 }
 
-func (app *App) performDelete(item interface{}) {
+// performDelete deletes an item of the current view; size is what the
+// item takes on disk, as returned by itemSize.
+func (app *App) performDelete(item interface{}, size int64) {
 	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
 
-	var err error
-	var itemName string
+	itemName := itemID(item)
+
+	var chain map[string]bool
+	if img, ok := item.(ImageInfo); ok {
+		chain = app.imageChainIDs(ctx, img.Name)
+	}
+
+	if err := app.deleteItem(ctx, item); err != nil {
+		class := classifyDeleteError(err)
+		if class == deleteNotFound {
+			app.updateStatus(fmt.Sprintf("[yellow]%s was already deleted[white], refreshing", itemName))
+			app.loadItems()
+			return
+		}
+
+		message := fmt.Sprintf("Failed to delete %s: %v", itemName, err)
+		if guidance := deleteGuidance(class, app.currentResource); guidance != "" {
+			message += "\n\n" + guidance
+		}
+		app.showError(message)
+		return
+	}
+
+	app.recordDeletion(app.currentNamespace, app.currentResource.String(), itemName)
+	app.contentChanged(app.currentNamespace)
+	app.updateStatus(fmt.Sprintf("[green]Deleted:[white] %s%s", itemName, freedNote(size)))
+	app.loadItems()
+	app.offerImageSnapshotCleanup(itemName, chain)
+}
+
+func (app *App) performDeleteAll(targets []deleteTarget) {
+	resource := app.currentResource
+	names, _ := targetNamespaces(targets)
+
+	app.updateStatus(fmt.Sprintf("[yellow]Deleting %d %s...", len(targets), resource))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		result, err := deleteItems(context.Background(), app, targets, app.config.CrossNamespaceDeleteAll)
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				app.showError(fmt.Sprintf("Refusing to delete %d %s: %v.", len(targets), resource, err))
+				return
+			}
+
+			for _, target := range result.deleted {
+				app.recordDeletion(target.namespace, resource.String(), itemID(target.item))
+			}
+
+			goneNote := ""
+			if result.goneCount > 0 {
+				goneNote = fmt.Sprintf(" (%d already gone)", result.goneCount)
+			}
 
+			if result.failCount > 0 {
+				summary := deleteFailureSummary(result.failures)
+				app.updateStatus(fmt.Sprintf("[yellow]Deleted %d items%s, %d failed (%s)%s", result.successCount, goneNote, result.failCount, summary, freedNote(result.freed)))
+
+				var guidance []string
+				for _, class := range deleteErrorClasses {
+					if result.failures[class] > 0 {
+						if text := deleteGuidance(class, resource); text != "" {
+							guidance = append(guidance, fmt.Sprintf("%d %s: %s", result.failures[class], class, text))
+						}
+					}
+				}
+				if len(guidance) > 0 {
+					app.showError(fmt.Sprintf("Deleted %d of %d %s; %s.\n\n%s",
+						result.successCount, len(targets), resource, summary, strings.Join(guidance, "\n\n")))
+				}
+			} else {
+				app.updateStatus(fmt.Sprintf("[green]Successfully deleted all %d items%s%s", result.successCount, goneNote, freedNote(result.freed)))
+			}
+
+			for _, name := range names {
+				app.contentChanged(name)
+			}
+			app.loadItems()
+		})
+	}()
+}
+
+// deleteTarget is an item to delete and the namespace it is in.
+type deleteTarget struct {
+	namespace string
+	item      interface{}
+}
+
+// errCrossNamespaceDelete refuses a Delete All whose items span several
+// namespaces, where one keypress could wipe more than the user looked at.
+var errCrossNamespaceDelete = errors.New("the items span several namespaces; set cross_namespace_delete_all in the config file to allow it")
+
+// targetNamespaces lists the namespaces of targets in order of first
+// appearance, with how many targets each holds.
+func targetNamespaces(targets []deleteTarget) ([]string, map[string]int) {
+	var names []string
+	counts := make(map[string]int)
+	for _, target := range targets {
+		if counts[target.namespace] == 0 {
+			names = append(names, target.namespace)
+		}
+		counts[target.namespace]++
+	}
+	return names, counts
+}
+
+// itemDeleter sizes and deletes items of any resource type. The App does
+// it through containerd.
+type itemDeleter interface {
+	itemSize(ctx context.Context, item interface{}) int64
+	deleteItem(ctx context.Context, item interface{}) error
+}
+
+// deleteAllResult counts the outcome of deleting several items in turn.
+type deleteAllResult struct {
+	successCount int
+	failCount    int
+	goneCount    int
+	failures     map[deleteErrorClass]int
+	deleted      []deleteTarget
+	freed        int64
+}
+
+// deleteItems deletes targets one after the other, each in its namespace.
+// Targets in several namespaces are refused unless crossNamespace is set.
+// Items that are already gone count as deleted, but neither free space
+// nor enter the history.
+func deleteItems(ctx context.Context, deleter itemDeleter, targets []deleteTarget, crossNamespace bool) (deleteAllResult, error) {
+	if names, _ := targetNamespaces(targets); len(names) > 1 && !crossNamespace {
+		return deleteAllResult{}, errCrossNamespaceDelete
+	}
+
+	result := deleteAllResult{failures: make(map[deleteErrorClass]int)}
+	for _, target := range targets {
+		itemCtx := namespaces.WithNamespace(ctx, target.namespace)
+		size := deleter.itemSize(itemCtx, target.item)
+
+		err := deleter.deleteItem(itemCtx, target.item)
+		switch class := classifyDeleteError(err); {
+		case err == nil:
+			result.deleted = append(result.deleted, target)
+			result.successCount++
+			result.freed += size
+		case class == deleteNotFound:
+			// Deleted meanwhile, by another client or along with an item
+			// deleted earlier in the loop; what was asked for is done
+			result.successCount++
+			result.goneCount++
+		default:
+			result.failures[class]++
+			result.failCount++
+		}
+	}
+	return result, nil
+}
+
+// deleteItem deletes one item of any resource type. Errors keep the
+// containerd error definitions, so classifyDeleteError can sort them.
+func (app *App) deleteItem(ctx context.Context, item interface{}) error {
 	switch v := item.(type) {
 	case ImageInfo:
-		itemName = v.Name
-		imageService := app.client.ImageService()
-		err = imageService.Delete(ctx, v.Name, images.SynchronousDelete())
+		return app.client.ImageService().Delete(ctx, v.Name, images.SynchronousDelete())
 
 	case ContainerInfo:
-		itemName = v.ID
-		container, e := app.client.LoadContainer(ctx, v.ID)
-		if e == nil {
-			err = container.Delete(ctx)
-		} else {
-			err = e
+		container, err := app.client.LoadContainer(ctx, v.ID)
+		if err != nil {
+			return err
 		}
+		return container.Delete(ctx)
 
 	case TaskInfo:
-		itemName = v.ID
-		container, e := app.client.LoadContainer(ctx, v.ID)
-		if e == nil {
-			task, e2 := container.Task(ctx, nil)
-			if e2 == nil {
-				_, err = task.Delete(ctx)
-			} else {
-				err = e2
-			}
-		} else {
-			err = e
+		if v.Orphaned {
+			return app.deleteOrphanedTask(ctx, v, false)
 		}
+		return app.deleteTask(ctx, v.ID)
 
 	case SnapshotInfo:
-		itemName = v.Key
-		snapshotter := app.client.SnapshotService(app.snapshotter)
-		err = snapshotter.Remove(ctx, v.Key)
+		return app.client.SnapshotService(v.Snapshotter).Remove(ctx, v.Key)
 
 	case ContentInfo:
-		itemName = v.Digest
-		contentStore := app.client.ContentStore()
-		dgst, parseErr := digest.Parse(v.Digest)
-		if parseErr != nil {
-			err = parseErr
-		} else {
-			err = contentStore.Delete(ctx, dgst)
+		dgst, err := digest.Parse(v.Digest)
+		if err != nil {
+			return err
 		}
+		return app.client.ContentStore().Delete(ctx, dgst)
 	}
+	return nil
+}
 
+// deleteTask deletes the task of a container according to its state.
+// Created tasks never ran user code, so their placeholder process is
+// killed as part of the delete; running and paused tasks are refused.
+func (app *App) deleteTask(ctx context.Context, id string) error {
+	container, err := app.client.LoadContainer(ctx, id)
 	if err != nil {
-		app.showError(fmt.Sprintf("Failed to delete %s: %v", itemName, err))
-		return
+		return err
 	}
 
-	app.updateStatus(fmt.Sprintf("[green]Deleted:[white] %s", itemName))
-	app.loadItems()
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var opts []containerd.ProcessDeleteOpts
+	switch status.Status {
+	case containerd.Created:
+		opts = append(opts, containerd.WithProcessKill)
+	case containerd.Running:
+		return fmt.Errorf("task is running; stop it before deleting: %w", errdefs.ErrFailedPrecondition)
+	case containerd.Paused, containerd.Pausing:
+		return fmt.Errorf("task is %s; resume and stop it before deleting: %w", status.Status, errdefs.ErrFailedPrecondition)
+	}
+
+	_, err = task.Delete(ctx, opts...)
+	return err
 }
 
-func (app *App) performDeleteAll() {
-	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+// orphanIsLive reports whether an orphaned task still has processes that
+// must be killed before it can be deleted.
+func orphanIsLive(task TaskInfo) bool {
+	switch containerd.ProcessStatus(task.Status) {
+	case containerd.Stopped, containerd.Unknown:
+		return false
+	}
+	return true
+}
 
-	successCount := 0
-	failCount := 0
+// deleteOrphanedTask removes a task whose container no longer exists,
+// going through the task service directly. Live processes are refused
+// unless kill is set; nothing else can stop them anymore, so they are
+// then killed and waited for, which blocks for up to 5 seconds.
+func (app *App) deleteOrphanedTask(ctx context.Context, task TaskInfo, kill bool) error {
+	taskService := app.client.TaskService()
 
-	for _, item := range app.itemCache {
-		var err error
+	if orphanIsLive(task) {
+		if !kill {
+			return fmt.Errorf("orphaned task is %s; delete it on its own to kill it: %w", task.Status, errdefs.ErrFailedPrecondition)
+		}
 
-		switch v := item.(type) {
-		case ImageInfo:
-			imageService := app.client.ImageService()
-			err = imageService.Delete(ctx, v.Name, images.SynchronousDelete())
+		_, err := taskService.Kill(ctx, &tasks.KillRequest{
+			ContainerID: task.ID,
+			Signal:      uint32(syscall.SIGKILL),
+			All:         true,
+		})
+		if err != nil && !errdefs.IsNotFound(errdefs.FromGRPC(err)) {
+			return errdefs.FromGRPC(err)
+		}
 
-		case ContainerInfo:
-			container, e := app.client.LoadContainer(ctx, v.ID)
-			if e == nil {
-				err = container.Delete(ctx)
+		// Give the runtime a moment to reap the processes
+		for i := 0; i < 50; i++ {
+			resp, err := taskService.Get(ctx, &tasks.GetRequest{ContainerID: task.ID})
+			if err != nil || resp.Process.Status == tasktypes.Status_STOPPED {
+				break
 			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
 
-		case TaskInfo:
-			container, e := app.client.LoadContainer(ctx, v.ID)
-			if e == nil {
-				task, e2 := container.Task(ctx, nil)
-				if e2 == nil {
-					_, err = task.Delete(ctx)
-				}
-			}
+	_, err := taskService.Delete(ctx, &tasks.DeleteTaskRequest{ContainerID: task.ID})
+	return errdefs.FromGRPC(err)
+}
 
-		case SnapshotInfo:
-			snapshotter := app.client.SnapshotService(app.snapshotter)
-			err = snapshotter.Remove(ctx, v.Key)
+// confirmKillOrphanedTask asks before killing the processes of a live
+// orphaned task to delete it. It is asked even when delete confirmation
+// is turned off, since the processes may still do useful work.
+func (app *App) confirmKillOrphanedTask(task TaskInfo) {
+	namespace := app.currentNamespace
 
-		case ContentInfo:
-			contentStore := app.client.ContentStore()
-			dgst, parseErr := digest.Parse(v.Digest)
-			if parseErr != nil {
-				err = parseErr
-			} else {
-				err = contentStore.Delete(ctx, dgst)
+	stopCountdown := func() {}
+	buttons := []string{"Kill and Delete", "Cancel"}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Kill orphaned task?\n\n%s\n\nThe task is %s but its container is gone, so nothing else can stop it. Its processes get SIGKILL, then the task is deleted.\nThis action cannot be undone!",
+			task.ID, task.Status)).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-kill")
+			if buttonLabel != "Kill and Delete" {
+				return
 			}
-		}
 
-		if err == nil {
-			successCount++
-		} else {
-			failCount++
-		}
+			app.updateStatus(fmt.Sprintf("[yellow]Killing orphaned task:[white] %s", task.ID))
+
+			// Run the blocking operation in a goroutine to prevent UI freeze
+			go func() {
+				ctx := namespaces.WithNamespace(context.Background(), namespace)
+				err := app.deleteOrphanedTask(ctx, task, true)
+				// Queue UI updates on the main thread
+				app.tviewApp.QueueUpdateDraw(func() {
+					if err != nil && classifyDeleteError(err) != deleteNotFound {
+						app.showError(fmt.Sprintf("Failed to delete orphaned task %s: %v", task.ID, err))
+						return
+					}
+					app.recordDeletion(namespace, ResourceTasks.String(), task.ID)
+					app.updateStatus(fmt.Sprintf("[green]Killed and deleted orphaned task:[white] %s", task.ID))
+					app.loadItems()
+				})
+			}()
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Kill Orphaned Task ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-kill", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, buttons, app.deleteCountdown)
 	}
+}
 
-	if failCount > 0 {
-		app.updateStatus(fmt.Sprintf("[yellow]Deleted %d items, %d failed", successCount, failCount))
-	} else {
-		app.updateStatus(fmt.Sprintf("[green]Successfully deleted all %d items", successCount))
+// itemSize returns the disk space accounted to an item, or 0 when it is
+// not known. Snapshot usage is looked up on demand since it isn't loaded
+// with the list.
+func (app *App) itemSize(ctx context.Context, item interface{}) int64 {
+	switch v := item.(type) {
+	case ImageInfo:
+		return v.Size
+	case ContentInfo:
+		return v.Size
+	case SnapshotInfo:
+		usage, err := app.client.SnapshotService(v.Snapshotter).Usage(ctx, v.Key)
+		if err != nil {
+			return 0
+		}
+		return usage.Size
 	}
+	return 0
+}
 
-	app.loadItems()
+// freedNote formats the freed space suffix for delete status messages.
+func freedNote(size int64) string {
+	if size <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", freed [green]%s[white]", formatSize(size))
 }
 
 func (app *App) tagImage() {
-	row, _ := app.itemTable.GetSelection()
-	if row <= 0 || row > len(app.itemCache) {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("tag")
 		return
 	}
 
-	item := app.itemCache[row-1]
 	img, ok := item.(ImageInfo)
 	if !ok {
 		return
@@ -960,20 +2669,17 @@ func (app *App) tagImage() {
 					app.performTag(imgName, tag)
 					// Queue UI updates on the main thread
 					app.tviewApp.QueueUpdateDraw(func() {
-						app.pages.RemovePage("tag")
 						app.tagInput = nil
-						app.tviewApp.SetFocus(app.itemTable)
+						app.closeDialog("tag")
 					})
 				}(img.Name, newTag)
 			} else {
-				app.pages.RemovePage("tag")
 				app.tagInput = nil
-				app.tviewApp.SetFocus(app.itemTable)
+				app.closeDialog("tag")
 			}
 		} else if key == tcell.KeyEscape {
-			app.pages.RemovePage("tag")
 			app.tagInput = nil
-			app.tviewApp.SetFocus(app.itemTable)
+			app.closeDialog("tag")
 		}
 	})
 
@@ -1027,24 +2733,100 @@ func (app *App) deleteSelectedNamespace() {
 		return
 	}
 
+	stopCountdown := func() {}
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("Delete entire namespace?\n\n%s\n\nWARNING: This will delete ALL resources in this namespace!\nThis action cannot be undone!", app.currentNamespace)).
 		AddButtons([]string{"Delete Namespace", "Cancel"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
 			if buttonLabel == "Delete Namespace" {
 				app.performDeleteNamespace(app.currentNamespace)
 			}
-			app.pages.RemovePage("confirm-ns")
-			app.tviewApp.SetFocus(app.namespaceList)
+			app.closeDialog("confirm-ns")
 		})
 
 	modal.SetBorder(true).SetTitle(" ⚠ Confirm Delete Namespace ")
 	modal.SetBackgroundColor(tcell.ColorDefault)
 
 	app.pages.AddPage("confirm-ns", modal, true, true)
+	stopCountdown = app.startConfirmCountdown(modal, []string{"Delete Namespace", "Cancel"}, app.deleteCountdown)
+}
+
+// startConfirmCountdown keeps every button of a confirm modal except the
+// trailing Cancel inactive for the given number of seconds. The buttons
+// are relabeled with the remaining time meanwhile, so their labels no
+// longer match what the done handler checks for and presses are ignored.
+// The returned func stops the countdown; done handlers call it so the
+// countdown doesn't keep relabeling a modal that was already closed.
+func (app *App) startConfirmCountdown(modal *tview.Modal, buttons []string, seconds int) (stop func()) {
+	if seconds <= 0 {
+		return func() {}
+	}
+
+	setRemaining := func(remaining int) {
+		labels := make([]string, len(buttons))
+		for i, label := range buttons {
+			if remaining > 0 && i < len(buttons)-1 {
+				label = fmt.Sprintf("%s (%d)", label, remaining)
+			}
+			labels[i] = label
+		}
+
+		hadFocus := modal.HasFocus()
+		modal.ClearButtons().
+			AddButtons(labels).
+			SetFocus(len(labels) - 1)
+		if hadFocus {
+			app.tviewApp.SetFocus(modal)
+		}
+	}
+
+	setRemaining(seconds)
+	app.tviewApp.SetFocus(modal)
+
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		for remaining := seconds - 1; remaining >= 0; remaining-- {
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Second):
+			}
+
+			remaining := remaining
+			app.tviewApp.QueueUpdateDraw(func() {
+				select {
+				case <-done:
+				default:
+					setRemaining(remaining)
+				}
+			})
+		}
+	}()
+
+	return func() {
+		once.Do(func() { close(done) })
+	}
 }
 
 func (app *App) performDeleteNamespace(namespaceName string) {
+	if err := app.removeNamespace(namespaceName); err != nil {
+		app.showError(fmt.Sprintf("Failed to delete namespace: %v", err))
+		return
+	}
+
+	app.recordDeletion(namespaceName, "Namespace", namespaceName)
+	app.contentChanged(namespaceName)
+	app.updateStatus(fmt.Sprintf("[green]Deleted namespace:[white] %s", namespaceName))
+	app.loadNamespaces()
+}
+
+// removeNamespace deletes the images and containers of a namespace, then
+// the namespace itself. It doesn't touch the UI, so it can run in the
+// background.
+func (app *App) removeNamespace(namespaceName string) error {
 	ctx := namespaces.WithNamespace(context.Background(), namespaceName)
 
 	// Delete all images
@@ -1061,16 +2843,7 @@ func (app *App) performDeleteNamespace(namespaceName string) {
 	}
 
 	// Delete namespace
-	namespaceSvc := app.client.NamespaceService()
-	err := namespaceSvc.Delete(context.Background(), namespaceName)
-
-	if err != nil {
-		app.showError(fmt.Sprintf("Failed to delete namespace: %v", err))
-		return
-	}
-
-	app.updateStatus(fmt.Sprintf("[green]Deleted namespace:[white] %s", namespaceName))
-	app.loadNamespaces()
+	return app.client.NamespaceService().Delete(context.Background(), namespaceName)
 }
 
 func (app *App) showHelp() {
@@ -1081,15 +2854,60 @@ func (app *App) showHelp() {
   [yellow]d[white]            - Delete selected item
   [yellow]D[white]            - Delete entire namespace (when in namespace panel)
   [yellow]a, A[white]         - Delete ALL items in current view
-  [yellow]t, T[white]         - Tag selected image (when in Images view)
-  [yellow]/[white]            - Search/filter items by name
+  [yellow]t, T[white]         - Tag selected image (Images view) / live processes of a task (Tasks view)
+  [yellow]p[white]            - Pull an image, optionally for another platform (when in Images view)
+  [yellow]m[white]            - Retag many images by pattern, e.g. for a registry move (when in Images view)
+  [yellow]R[white]            - Run a container from the image with a chosen runtime (Images view) / restart a container (Containers, Tasks view) / rename namespace (namespace panel)
+  [yellow]=[white]            - Compare the layers of two marked images (Images view)
+  [yellow]S[white]            - Cycle image size: config+layers / layers only / not shared (Images view)
+  [yellow]M[white]            - Open the image manifest and config in $PAGER or $EDITOR (Images view)
+  [yellow]V[white]            - Verify that marked or selected images have all their blobs (Images view)
+  [yellow]y[white]            - Copy or save the layer digests of the selected image (Images view)
+  [yellow]J[white]            - Jump to the config blob of the selected image in the Content view (Images view)
+  [yellow]E[white]            - Show only images older than 7d / 30d / 90d / all images (Images view)
+  [yellow]O[white]            - Show only images no container uses / all images (Images view)
+  [yellow]U[white]            - List tags that share the same content (Images view)
+  [yellow]&[white]            - Delete the selected image with all tags sharing its content (Images view)
+  [yellow]X[white]            - Delete images whose containerd.io/gc.expire label has passed (Images view)
+  [yellow]s[white]            - Toggle snapshots of all snapshotters (when in Snapshots view)
+  [yellow]K[white]            - Toggle short labels for generated snapshot keys (when in Snapshots view)
+  [yellow]C[white]            - Toggle coloring containers by age (when in Containers view)
+  [yellow]I[white]            - Cycle container IDs: short for generated / short / full (Containers, Tasks view)
+  [yellow]e[white]            - Export selected blob to a file (when in Content view)
+  [yellow]c[white]            - Copy marked or selected blobs to another namespace (when in Content view)
+  [yellow]f[white]            - Toggle full / truncated digests (Content view) / pause, resume following (log view; scrolling up pauses too)
+  [yellow]B[white]            - Delete the leases holding the selected blob (when in Content view)
+  [yellow]P[white]            - Push image (Images view) / prune unused snapshots (Snapshots view) / build cache (Content view of buildkit)
+  [yellow]u[white]            - Show disk usage of every namespace (w: export CSV)
+  [yellow]H[white]            - Show what was deleted in this session
+  [yellow]x[white]            - Hide / show resource types without items in the namespace (when in resources panel)
+  [yellow]![white]            - Check that containerd answers, reconnecting if not
+  [yellow]Y[white]            - Copy a one-line summary of the current namespace
+  [yellow]W[white]            - Show where containerd keeps its data on disk
+  [yellow]Z[white]            - Toggle keeping the selected item / selecting the first row on refresh
+  [yellow]F[white]            - Keep the selection on the newest item as refreshes add items
+  [yellow]Ctrl-R[white]       - Refresh the current view now, keeping search, marks and selection
+  [yellow]+ / -[white]        - Lengthen / shorten the auto-refresh interval (off after 1m)
+  [yellow]b[white]            - Toggle sizes between human readable and exact bytes
+  [yellow]v[white]            - Toggle tree / flat view (Images by repository, Containers by pod, Snapshots by parent)
+  [yellow]Space[white]        - Mark/unmark selected item
+  [yellow]L[white]            - Follow logs of marked or selected containers (Containers/Tasks view) / namespace labels (namespace panel)
+  [yellow]w[white]            - Watch the status of the selected container or task (Containers/Tasks view)
+  [yellow]/[white]            - Search/filter items by name (Ctrl-T in the search box: toggle case sensitivity)
+  [yellow]|[white]            - Filter by column: one input per column, all must match (Tab: next column, Esc: clear)
+  [yellow]*[white]            - Search all resource types of the namespace and jump to a match
   [yellow]1-5[white]          - Quick jump to resource (1:Images 2:Containers 3:Tasks 4:Snapshots 5:Content)
+  [yellow]o[white]            - Order namespaces alphabetically / by item count (when in namespace panel)
+  [yellow]N[white]            - Reload the namespace list, keeping the current selection
+  [yellow]< / >[white]        - Shrink / grow the items panel (remembered between runs)
+  [yellow]n / r / i[white]    - Focus Namespaces / Resources / Items panel (r: friendly / raw JSON in the details view)
   [yellow]Tab[white]          - Cycle focus: Namespaces → Resources → Items
   [yellow]Shift+Tab[white]    - Cycle focus backward
   [yellow]?[white]            - Show this help
   [yellow]↑/↓[white]          - Navigate lists
-  [yellow]Enter[white]        - Close search box (keep filter active)
-  [yellow]Esc[white]          - Clear search filter / Close dialog
+  [yellow]PgUp/PgDn[white]    - Scroll items, turning pages at the edges
+  [yellow]Enter[white]        - Open the items of the selected namespace or resource type / Show details of selected item (Images, with their build history; Containers) / what references it (Snapshots) / Close search box
+  [yellow]Esc[white]          - Close or cancel dialog / Clear search filter
 
 [yellow]Resource Types:[white]
 
@@ -1108,36 +2926,237 @@ func (app *App) showHelp() {
   5. Use '/' to search/filter items
 
 [yellow]Note:[white] Requires root/sudo access to containerd socket.
-Restart to refresh. Be careful with delete operations!
+Views refresh with Ctrl-R or auto-refresh (+ / -), namespaces with N.
+Be careful with delete operations!
 `
 
 	modal := tview.NewModal().
 		SetText(helpContent).
 		AddButtons([]string{"Close"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.pages.RemovePage("help")
-			app.tviewApp.SetFocus(app.itemTable)
+			app.closeDialog("help")
 		})
 
 	modal.SetBorder(true).SetTitle(" Help ")
 	app.pages.AddPage("help", modal, true, true)
 }
 
+// quit stops the application, asking first if marked items would be
+// discarded.
+func (app *App) quit() {
+	marked := len(app.markedItems())
+	if marked == 0 {
+		app.tviewApp.Stop()
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Quit and discard %d marked %s?", marked, strings.ToLower(app.currentResource.String()))).
+		AddButtons([]string{"Quit", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonLabel == "Quit" {
+				app.tviewApp.Stop()
+				return
+			}
+			app.closeDialog("confirm-quit")
+		})
+
+	modal.SetBorder(true).SetTitle(" Confirm Quit ")
+	app.pages.AddPage("confirm-quit", modal, true, true)
+}
+
 func (app *App) showError(message string) {
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("[red]Error[white]\n\n%s", message)).
 		AddButtons([]string{"OK"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			app.pages.RemovePage("error")
-			app.tviewApp.SetFocus(app.itemTable)
+			app.closeDialog("error")
 		})
 
 	modal.SetBorder(true).SetTitle(" Error ")
 	app.pages.AddPage("error", modal, true, true)
 }
 
+// permissionHint suggests how to get access to the containerd socket when
+// an error says access was denied: a gRPC PermissionDenied status, or
+// failing to dial the socket. gRPC flattens the EACCES of a failed dial
+// into the message of an Unavailable status, so that is checked for in
+// the text. Other permission errors, e.g. writing an export, get none.
+func permissionHint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	denied := errors.Is(err, os.ErrPermission)
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.PermissionDenied:
+			denied = true
+		case codes.Unavailable:
+			message := s.Message()
+			denied = strings.Contains(message, "dial unix") && strings.Contains(message, "permission denied")
+		}
+	}
+	if !denied {
+		return ""
+	}
+	return "\n\nThe containerd socket is usually only accessible to root. Run lazyctr with sudo, " +
+		"or set 'gid' in the [grpc] section of /etc/containerd/config.toml to a group you belong to and restart containerd."
+}
+
+// closeDialog removes a dialog page. Once no other dialog is left open,
+// the focus goes back to the panel that had it before the first dialog
+// opened; otherwise the dialog below keeps it, so a dialog opened on top
+// of another, e.g. an error, never strands the focus behind it.
+func (app *App) closeDialog(name string) {
+	app.pages.RemovePage(name)
+	if front, _ := app.pages.GetFrontPage(); front == "main" {
+		app.tviewApp.SetFocus(app.panelFocus)
+	}
+}
+
+// trackPanelFocus remembers the focused panel whenever a dialog is about
+// to open over the main page. Pages reports changes before moving the
+// focus to a new page.
+func (app *App) trackPanelFocus() {
+	switch focus := app.tviewApp.GetFocus(); focus {
+	case app.namespaceList, app.resourceList, app.itemTable:
+		app.panelFocus = focus
+	}
+}
+
+// inputHasFocus reports whether a text input currently owns the keyboard,
+// in which case global shortcuts must not fire.
+func (app *App) inputHasFocus() bool {
+	_, ok := app.tviewApp.GetFocus().(*tview.InputField)
+	return ok
+}
+
+// copyToClipboard places text on the system clipboard using the terminal's
+// OSC 52 support. Terminals without it silently ignore the request.
+func (app *App) copyToClipboard(text string) {
+	if app.screen == nil {
+		return
+	}
+	app.screen.SetClipboard([]byte(text))
+}
+
+// statusInterval is the minimum time between status bar redraws. Updates
+// arriving faster are coalesced and only the latest one is shown.
+const statusInterval = 100 * time.Millisecond
+
 func (app *App) updateStatus(message string) {
-	app.statusBar.SetText(fmt.Sprintf(" %s", message))
+	app.statusMu.Lock()
+	defer app.statusMu.Unlock()
+
+	app.pendingStatus = fmt.Sprintf(" %s", message)
+	if app.statusTimer != nil {
+		return // a flush is already scheduled and will pick this up
+	}
+
+	wait := statusInterval - time.Since(app.lastStatus)
+	if wait <= 0 {
+		app.statusBar.SetText(app.pendingStatus)
+		app.lastStatus = time.Now()
+		return
+	}
+
+	app.statusTimer = time.AfterFunc(wait, func() {
+		app.tviewApp.QueueUpdateDraw(app.flushStatus)
+	})
+}
+
+// flushStatus shows the latest coalesced status message.
+func (app *App) flushStatus() {
+	app.statusMu.Lock()
+	defer app.statusMu.Unlock()
+
+	app.statusBar.SetText(app.pendingStatus)
+	app.lastStatus = time.Now()
+	app.statusTimer = nil
+}
+
+// Definitions of image size selectable for the Images view.
+const (
+	imageSizeTotal  = ""
+	imageSizeLayers = "layers"
+	imageSizeUnique = "unique"
+)
+
+var imageSizeModes = []string{imageSizeTotal, imageSizeLayers, imageSizeUnique}
+
+var imageSizeHeaders = map[string]string{
+	imageSizeTotal:  "Size (config+layers)",
+	imageSizeLayers: "Size (layers only)",
+	imageSizeUnique: "Size (not shared)",
+}
+
+// imageSize returns the size of an image under the selected definition.
+func (app *App) imageSize(img ImageInfo) int64 {
+	switch app.config.ImageSize {
+	case imageSizeLayers:
+		return img.LayersSize
+	case imageSizeUnique:
+		return img.UniqueSize
+	}
+	return img.Size
+}
+
+// cycleImageSize switches to the next definition of image size and
+// remembers it for the next run.
+func (app *App) cycleImageSize() {
+	next := (slices.Index(imageSizeModes, app.config.ImageSize) + 1) % len(imageSizeModes)
+	app.config.ImageSize = imageSizeModes[next]
+
+	index := app.selectedIndex()
+	app.renderItemTable()
+	app.selectIndex(index)
+
+	header := imageSizeHeaders[app.config.ImageSize]
+	app.saveSetting("Image size", header)
+}
+
+// sizeText formats a size for the size columns, either human readable or,
+// when raw sizes are enabled, as an exact byte count.
+func (app *App) sizeText(bytes int64) string {
+	if app.config.RawSizes {
+		return formatBytes(bytes)
+	}
+	return formatSize(bytes)
+}
+
+// formatBytes formats an exact byte count with thousands separators.
+func formatBytes(bytes int64) string {
+	digits := strconv.FormatInt(bytes, 10)
+	sign := ""
+	if bytes < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String() + " B"
+}
+
+// toggleRawSizes switches the size columns between human readable sizes
+// and exact byte counts and remembers the choice for the next run.
+func (app *App) toggleRawSizes() {
+	app.config.RawSizes = !app.config.RawSizes
+
+	index := app.selectedIndex()
+	app.renderItemTable()
+	app.selectIndex(index)
+
+	mode := "human readable"
+	if app.config.RawSizes {
+		mode = "bytes"
+	}
+	app.saveSetting("Sizes", mode)
 }
 
 func formatSize(bytes int64) string {
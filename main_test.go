@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+)
+
+// fakeDeleter is a store of blobs by digest. Deleting a blob also removes
+// the blobs it takes along, like an image delete removing its layers.
+type fakeDeleter struct {
+	blobs  map[string]int64
+	along  map[string][]string
+	refuse map[string]error
+	// Namespace of each delete, in order
+	namespaces []string
+}
+
+func (f *fakeDeleter) itemSize(ctx context.Context, item interface{}) int64 {
+	return f.blobs[itemID(item)]
+}
+
+func (f *fakeDeleter) deleteItem(ctx context.Context, item interface{}) error {
+	namespace, _ := namespaces.Namespace(ctx)
+	f.namespaces = append(f.namespaces, namespace)
+
+	id := itemID(item)
+	if err, ok := f.refuse[id]; ok {
+		return err
+	}
+	if _, ok := f.blobs[id]; !ok {
+		return fmt.Errorf("content digest %s: %w", id, errdefs.ErrNotFound)
+	}
+	delete(f.blobs, id)
+	for _, other := range f.along[id] {
+		delete(f.blobs, other)
+	}
+	return nil
+}
+
+func TestDeleteItems(t *testing.T) {
+	targets := []deleteTarget{
+		{namespace: "default", item: ContentInfo{Digest: "sha256:a"}},
+		{namespace: "default", item: ContentInfo{Digest: "sha256:b"}},
+		{namespace: "default", item: ContentInfo{Digest: "sha256:c"}},
+	}
+
+	tests := []struct {
+		name     string
+		deleter  *fakeDeleter
+		success  int
+		gone     int
+		fail     int
+		failures map[deleteErrorClass]int
+		deleted  []string
+		freed    int64
+	}{
+		{
+			name:    "all deleted",
+			deleter: &fakeDeleter{blobs: map[string]int64{"sha256:a": 1, "sha256:b": 2, "sha256:c": 4}},
+			success: 3,
+			deleted: []string{"sha256:a", "sha256:b", "sha256:c"},
+			freed:   7,
+		},
+		{
+			name:    "deleted along with an earlier item",
+			deleter: &fakeDeleter{blobs: map[string]int64{"sha256:a": 1, "sha256:b": 2, "sha256:c": 4}, along: map[string][]string{"sha256:a": {"sha256:b"}}},
+			success: 3,
+			gone:    1,
+			deleted: []string{"sha256:a", "sha256:c"},
+			freed:   5,
+		},
+		{
+			name:    "deleted by another client",
+			deleter: &fakeDeleter{blobs: map[string]int64{"sha256:b": 2}},
+			success: 3,
+			gone:    2,
+			deleted: []string{"sha256:b"},
+			freed:   2,
+		},
+		{
+			name: "gone and failed",
+			deleter: &fakeDeleter{
+				blobs:  map[string]int64{"sha256:a": 1, "sha256:b": 2},
+				refuse: map[string]error{"sha256:b": fmt.Errorf("blob is leased: %w", errdefs.ErrFailedPrecondition)},
+			},
+			success:  2,
+			gone:     1,
+			fail:     1,
+			failures: map[deleteErrorClass]int{deleteInUse: 1},
+			deleted:  []string{"sha256:a"},
+			freed:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := deleteItems(context.Background(), tt.deleter, targets, false)
+			if err != nil {
+				t.Fatalf("deleteItems: %v", err)
+			}
+			if result.successCount != tt.success || result.goneCount != tt.gone || result.failCount != tt.fail {
+				t.Errorf("got %d deleted, %d gone, %d failed; want %d, %d, %d",
+					result.successCount, result.goneCount, result.failCount, tt.success, tt.gone, tt.fail)
+			}
+			for class, count := range tt.failures {
+				if result.failures[class] != count {
+					t.Errorf("got %d %s failures, want %d", result.failures[class], class, count)
+				}
+			}
+			var deleted []string
+			for _, target := range result.deleted {
+				deleted = append(deleted, itemID(target.item))
+			}
+			if !slices.Equal(deleted, tt.deleted) {
+				t.Errorf("got deleted %v, want %v", deleted, tt.deleted)
+			}
+			if result.freed != tt.freed {
+				t.Errorf("got %d bytes freed, want %d", result.freed, tt.freed)
+			}
+		})
+	}
+}
+
+func TestDeleteItemsAcrossNamespaces(t *testing.T) {
+	targets := []deleteTarget{
+		{namespace: "default", item: ContentInfo{Digest: "sha256:a"}},
+		{namespace: "k8s.io", item: ContentInfo{Digest: "sha256:b"}},
+		{namespace: "default", item: ContentInfo{Digest: "sha256:c"}},
+	}
+
+	tests := []struct {
+		name           string
+		crossNamespace bool
+		wantErr        error
+		success        int
+		namespaces     []string
+	}{
+		{
+			name:    "refused",
+			wantErr: errCrossNamespaceDelete,
+		},
+		{
+			name:           "allowed",
+			crossNamespace: true,
+			success:        3,
+			namespaces:     []string{"default", "k8s.io", "default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleter := &fakeDeleter{blobs: map[string]int64{"sha256:a": 1, "sha256:b": 2, "sha256:c": 4}}
+			result, err := deleteItems(context.Background(), deleter, targets, tt.crossNamespace)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if result.successCount != tt.success {
+				t.Errorf("got %d deleted, want %d", result.successCount, tt.success)
+			}
+			if !slices.Equal(deleter.namespaces, tt.namespaces) {
+				t.Errorf("deleted in namespaces %v, want %v", deleter.namespaces, tt.namespaces)
+			}
+		})
+	}
+}
+
+func TestTargetNamespaces(t *testing.T) {
+	names, counts := targetNamespaces([]deleteTarget{
+		{namespace: "k8s.io"}, {namespace: "default"}, {namespace: "k8s.io"},
+	})
+	if !slices.Equal(names, []string{"k8s.io", "default"}) {
+		t.Errorf("got namespaces %v, want [k8s.io default]", names)
+	}
+	if counts["k8s.io"] != 2 || counts["default"] != 1 {
+		t.Errorf("got counts %v, want k8s.io:2 default:1", counts)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showNamespaceLabels lists the labels of the current namespace. CRI and
+// other clients use them for namespace-wide settings.
+func (app *App) showNamespaceLabels() {
+	namespace := app.currentNamespace
+	if namespace == "" {
+		return
+	}
+
+	labels, err := app.client.NamespaceService().Labels(context.Background(), namespace)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to load labels of %s: %v", namespace, err))
+		return
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"Label", "Value"} {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, key := range keys {
+		table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(key)).SetTextColor(tcell.ColorTeal))
+		table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(labels[key])).SetTextColor(tcell.ColorWhite).SetExpansion(1))
+	}
+	if len(keys) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("No labels").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+	}
+
+	closeLabels := func() {
+		app.closeDialog("ns-labels")
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			closeLabels()
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'a':
+			app.addNamespaceLabel(namespace)
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'd':
+			row, _ := table.GetSelection()
+			if row > 0 && row <= len(keys) {
+				app.removeNamespaceLabel(namespace, keys[row-1])
+			}
+			return nil
+		}
+		return event
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Labels of %s (a: add/set, d: remove, Esc: close) ", namespace)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 6, true).
+			AddItem(nil, 0, 1, false), min(len(keys)+4, 20), 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.RemovePage("ns-labels")
+	app.pages.AddPage("ns-labels", modal, true, true)
+	app.tviewApp.SetFocus(table)
+}
+
+// addNamespaceLabel prompts for a key=value label and sets it on the
+// namespace, replacing any existing value of the key.
+func (app *App) addNamespaceLabel(namespace string) {
+	labelInput := tview.NewInputField().
+		SetLabel("Label (key=value): ").
+		SetFieldWidth(50)
+
+	labelInput.SetDoneFunc(func(key tcell.Key) {
+		text := strings.TrimSpace(labelInput.GetText())
+		app.pages.RemovePage("ns-label-add")
+
+		if key != tcell.KeyEnter || text == "" {
+			app.showNamespaceLabels()
+			return
+		}
+
+		name, value, ok := strings.Cut(text, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			app.showError(fmt.Sprintf("Invalid label %q, expected key=value", text))
+			return
+		}
+
+		if err := app.client.NamespaceService().SetLabel(context.Background(), namespace, name, value); err != nil {
+			app.showError(fmt.Sprintf("Failed to set label %s on %s: %v", name, namespace, err))
+			return
+		}
+		app.updateStatus(fmt.Sprintf("[green]Set label:[white] %s=%s on %s", tview.Escape(name), tview.Escape(value), namespace))
+		app.showNamespaceLabels()
+	})
+
+	form := tview.NewForm().
+		AddFormItem(labelInput)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Set Label on %s ", namespace)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("ns-label-add", modal, true, true)
+	app.tviewApp.SetFocus(labelInput)
+}
+
+// removeNamespaceLabel asks before removing a label. containerd removes a
+// label when it is set to an empty value.
+func (app *App) removeNamespaceLabel(namespace, key string) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Remove label from namespace '%s'?\n\n%s", namespace, tview.Escape(key))).
+		AddButtons([]string{"Remove", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			app.pages.RemovePage("confirm-ns-label")
+			if buttonLabel == "Remove" {
+				if err := app.client.NamespaceService().SetLabel(context.Background(), namespace, key, ""); err != nil {
+					app.showError(fmt.Sprintf("Failed to remove label %s from %s: %v", key, namespace, err))
+					return
+				}
+				app.updateStatus(fmt.Sprintf("[green]Removed label:[white] %s from %s", tview.Escape(key), namespace))
+			}
+			app.showNamespaceLabels()
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Remove Label ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-ns-label", modal, true, true)
+}
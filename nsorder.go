@@ -0,0 +1,193 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/namespaces"
+)
+
+// Namespace orders of the namespace panel, as stored in the config file.
+const (
+	namespaceSortName  = ""
+	namespaceSortCount = "count"
+)
+
+var namespaceSortModes = []string{namespaceSortName, namespaceSortCount}
+
+// sortNamespaces orders namespaces alphabetically or, by count, with the
+// namespaces holding the most items first. Namespaces whose items are not
+// counted yet go last, alphabetically.
+func (app *App) sortNamespaces(nsList []string) {
+	slices.Sort(nsList)
+	if app.config.NamespaceSort != namespaceSortCount {
+		return
+	}
+
+	slices.SortStableFunc(nsList, func(a, b string) int {
+		countA, okA := app.namespaceCounts[a]
+		countB, okB := app.namespaceCounts[b]
+		switch {
+		case okA && okB:
+			return cmp.Compare(countB, countA)
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return 0
+	})
+}
+
+// setNamespaces fills the namespace panel with nsList in the configured
+// order, keeping the current namespace selected if it is listed, and
+// returns the sorted list.
+func (app *App) setNamespaces(nsList []string) []string {
+	nsList = slices.Clone(nsList)
+	app.sortNamespaces(nsList)
+	app.fillNamespaceList(nsList)
+
+	if app.config.NamespaceSort == namespaceSortCount {
+		app.countNamespaces(nsList)
+	}
+	return nsList
+}
+
+// fillNamespaceList replaces the items of the namespace panel, keeping the
+// current namespace selected if it is listed.
+func (app *App) fillNamespaceList(nsList []string) {
+	// Rebuild the list without reloading items for intermediate selections
+	app.namespaceList.SetChangedFunc(nil)
+	app.namespaceList.Clear()
+	for _, ns := range nsList {
+		app.namespaceList.AddItem(ns, "", 0, nil)
+	}
+	app.namespaceList.SetCurrentItem(max(slices.Index(nsList, app.currentNamespace), 0))
+	app.namespaceList.SetChangedFunc(app.namespaceChanged)
+}
+
+// namespaceNames returns the namespaces currently in the namespace panel.
+func (app *App) namespaceNames() []string {
+	names := make([]string, app.namespaceList.GetItemCount())
+	for i := range names {
+		names[i], _ = app.namespaceList.GetItemText(i)
+	}
+	return names
+}
+
+// toggleNamespaceSort switches the namespace panel between alphabetical
+// order and the order by item count.
+func (app *App) toggleNamespaceSort() {
+	if app.config.NamespaceSort == namespaceSortCount {
+		app.config.NamespaceSort = namespaceSortName
+	} else {
+		app.config.NamespaceSort = namespaceSortCount
+	}
+	app.setNamespaces(app.namespaceNames())
+
+	order := "alphabetical"
+	if app.config.NamespaceSort == namespaceSortCount {
+		order = "by item count (counting…)"
+	}
+	app.saveSetting("Namespace order", order)
+}
+
+// countNamespaces counts the items of every namespace in the background and
+// reorders the namespace panel once done.
+func (app *App) countNamespaces(nsList []string) {
+	if app.countingNamespaces {
+		return
+	}
+	app.countingNamespaces = true
+	snapshotters := app.shownSnapshotters()
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		counts := make(map[string]int, len(nsList))
+		for _, ns := range nsList {
+			if count, err := app.countNamespaceItems(ns, snapshotters); err == nil {
+				counts[ns] = count
+			}
+		}
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.countingNamespaces = false
+			app.namespaceCounts = counts
+			if app.config.NamespaceSort != namespaceSortCount {
+				return
+			}
+
+			names := app.namespaceNames()
+			sorted := slices.Clone(names)
+			app.sortNamespaces(sorted)
+			if !slices.Equal(names, sorted) {
+				app.fillNamespaceList(sorted)
+			}
+		})
+	}()
+}
+
+// countNamespaceItems counts the items of every resource type of a
+// namespace together.
+func (app *App) countNamespaceItems(namespace string, snapshotters []string) (int, error) {
+	counts, err := app.countResourceItems(namespace, snapshotters)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return total, nil
+}
+
+// countResourceItems counts the items of each resource type of a
+// namespace, listing them without the per-item lookups the views do.
+// Snapshots are counted in the given snapshotters.
+func (app *App) countResourceItems(namespace string, snapshotters []string) (map[ResourceType]int, error) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	imageList, err := app.client.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	containerList, err := app.client.ContainerService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.client.TaskService().List(ctx, &tasks.ListTasksRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotCount := 0
+	for _, name := range snapshotters {
+		snapshotList, err := app.walkSnapshots(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		snapshotCount += len(snapshotList)
+	}
+
+	blobs := 0
+	err = app.client.ContentStore().Walk(ctx, func(content.Info) error {
+		blobs++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[ResourceType]int{
+		ResourceImages:     len(imageList),
+		ResourceContainers: len(containerList),
+		ResourceTasks:      len(resp.Tasks),
+		ResourceSnapshots:  snapshotCount,
+		ResourceContent:    blobs,
+	}, nil
+}
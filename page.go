@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// defaultPageSize is how many items the items panel renders at once.
+// Larger stores are paged so the table stays quick to draw and scroll.
+const defaultPageSize = 1000
+
+// pageItems returns the window of itemCache the items panel shows.
+func (app *App) pageItems() []interface{} {
+	end := min(app.pageStart+app.pageSize, len(app.itemCache))
+	return app.itemCache[app.pageStart:end]
+}
+
+// clampPage keeps the page start on a page boundary within itemCache.
+func (app *App) clampPage() {
+	if app.pageStart >= len(app.itemCache) {
+		app.pageStart = max(len(app.itemCache)-1, 0) / app.pageSize * app.pageSize
+	}
+}
+
+// pageNote describes the shown window when not everything fits on a page.
+func (app *App) pageNote() string {
+	if len(app.itemCache) <= app.pageSize {
+		return ""
+	}
+	return fmt.Sprintf(" showing %d–%d of %d", app.pageStart+1, app.pageStart+len(app.pageItems()), len(app.itemCache))
+}
+
+// selectedIndex returns the index in itemCache of the selected row, or -1.
+func (app *App) selectedIndex() int {
+	row, _ := app.itemTable.GetSelection()
+	if row <= 0 || row > len(app.pageItems()) {
+		return -1
+	}
+	return app.pageStart + row - 1
+}
+
+// selectedItem returns the item of the selected row.
+func (app *App) selectedItem() (interface{}, bool) {
+	index := app.selectedIndex()
+	if index < 0 {
+		return nil, false
+	}
+	return app.itemCache[index], true
+}
+
+// reportNothingSelected acknowledges a key that acts on the selected item
+// when the view has no item to act on.
+func (app *App) reportNothingSelected(action string) {
+	app.updateStatus(fmt.Sprintf("[yellow]Nothing to %s[white] (no %s in this view)", action, strings.ToLower(app.currentResource.String())))
+}
+
+// selectIndex selects the item at an index of itemCache, turning to its
+// page first if needed.
+func (app *App) selectIndex(index int) {
+	if index < 0 || index >= len(app.itemCache) {
+		return
+	}
+	if index < app.pageStart || index >= app.pageStart+app.pageSize {
+		app.pageStart = index / app.pageSize * app.pageSize
+		app.renderItemTable()
+	}
+	app.itemTable.Select(index-app.pageStart+1, 0)
+}
+
+// pageKey moves past the edges of the current page: PgDn or Down on its
+// last row continue on the next page, PgUp or Up on its first row on the
+// previous one. It reports whether the key was handled.
+func (app *App) pageKey(event *tcell.EventKey) bool {
+	index := app.selectedIndex()
+	if index < 0 || len(app.itemCache) <= app.pageSize {
+		return false
+	}
+
+	row := index - app.pageStart
+	switch event.Key() {
+	case tcell.KeyPgDn, tcell.KeyDown:
+		if row == len(app.pageItems())-1 && app.pageStart+app.pageSize < len(app.itemCache) {
+			app.selectIndex(app.pageStart + app.pageSize)
+			return true
+		}
+	case tcell.KeyPgUp, tcell.KeyUp:
+		if row == 0 && app.pageStart > 0 {
+			app.selectIndex(app.pageStart - 1)
+			return true
+		}
+	}
+	return false
+}
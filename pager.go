@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// openManifestInPager shows the selected image's manifest and config in
+// the user's $PAGER, or read-only in $EDITOR when no pager is set. The UI
+// is suspended until the program exits.
+func (app *App) openManifestInPager() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("open")
+		return
+	}
+	info, ok := item.(ImageInfo)
+	if !ok {
+		return
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	img, err := app.client.ImageService().Get(ctx, info.Name)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to load %s: %v", info.Name, err))
+		return
+	}
+
+	document, err := imageManifestDocument(ctx, app.client.ContentStore(), img)
+	if err != nil {
+		app.showError(fmt.Sprintf("Failed to read manifest of %s: %v", info.Name, err))
+		return
+	}
+
+	var runErr error
+	app.tviewApp.Suspend(func() {
+		runErr = runPager(document)
+	})
+	if runErr != nil {
+		app.showError(fmt.Sprintf("Failed to open manifest of %s: %v", info.Name, runErr))
+	}
+}
+
+// imageManifestDocument renders the JSON documents of an image: its
+// target, the host's manifest when the target is an index, and the config.
+func imageManifestDocument(ctx context.Context, store content.Store, img images.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Image: %s\n", img.Name)
+
+	appendBlob := func(title string, desc ocispec.Descriptor) ([]byte, error) {
+		data, err := content.ReadBlob(ctx, store, desc)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "\n# %s: %s (%s)\n", title, desc.Digest, desc.MediaType)
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			buf.Write(data)
+		}
+		buf.WriteString("\n")
+		return data, nil
+	}
+
+	data, err := appendBlob("Target", img.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestDesc := img.Target
+	if images.IsIndexType(img.Target.MediaType) {
+		var index ocispec.Index
+		if err := json.Unmarshal(data, &index); err != nil {
+			return nil, err
+		}
+		if len(index.Manifests) == 0 {
+			return buf.Bytes(), nil
+		}
+		manifestDesc = index.Manifests[0]
+		host := platforms.Default()
+		for _, desc := range index.Manifests {
+			if desc.Platform != nil && host.Match(*desc.Platform) {
+				manifestDesc = desc
+				break
+			}
+		}
+		if data, err = appendBlob("Manifest", manifestDesc); err != nil {
+			return nil, err
+		}
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Config.Digest != "" {
+		if _, err := appendBlob("Config", manifest.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// runPager pipes document to $PAGER. Without a pager it opens a read-only
+// temporary file in $EDITOR, and falls back to less.
+func runPager(document []byte) error {
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	editor := strings.TrimSpace(os.Getenv("EDITOR"))
+
+	if pager == "" && editor != "" {
+		f, err := os.CreateTemp("", "lazyctr-manifest-*.json")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+
+		_, err = f.Write(document)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Chmod(f.Name(), 0o400)
+		}
+		if err != nil {
+			return err
+		}
+		return runShell(editor+` "$1"`, nil, f.Name())
+	}
+
+	if pager == "" {
+		pager = "less"
+	}
+	return runShell(pager, bytes.NewReader(document))
+}
+
+// runShell runs command through sh so $PAGER and $EDITOR may carry
+// arguments, attached to the terminal.
+func runShell(command string, stdin io.Reader, args ...string) error {
+	cmd := exec.Command("sh", append([]string{"-c", command, "sh"}, args...)...)
+	cmd.Stdin = os.Stdin
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
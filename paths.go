@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// snapshotDirs names the directory under a snapshotter's root that holds
+// one directory per snapshot, for the snapshotters with a known layout.
+var snapshotDirs = map[string]string{
+	"overlayfs": "snapshots",
+	"native":    "snapshots",
+}
+
+// diskPath is one row of the paths view.
+type diskPath struct {
+	name string
+	path string
+	note string
+}
+
+// diskPaths lists where containerd keeps its data, from the root
+// directories the content and snapshotter plugins export. containerd
+// doesn't export its root or state directory itself; the root is the
+// parent of the content store, and the state directory is assumed to be
+// that of the socket, which holds for the default configuration only.
+func (app *App) diskPaths(ctx context.Context, namespace string) ([]diskPath, error) {
+	content, err := app.pluginExports("io.containerd.content.v1")
+	if err != nil {
+		return nil, err
+	}
+	snapshotters, err := app.pluginExports("io.containerd.snapshotter.v1")
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []diskPath
+	if contentRoot := content["content"]["root"]; contentRoot != "" {
+		root := filepath.Dir(contentRoot)
+		paths = append(paths,
+			diskPath{name: "Root", path: root},
+			diskPath{name: "State", path: filepath.Dir(socketPath), note: "assumed: directory of the socket"},
+			diskPath{name: "Metadata", path: filepath.Join(root, "io.containerd.metadata.v1.bolt", "meta.db"), note: "all namespaces"},
+			diskPath{name: "Content blobs", path: filepath.Join(contentRoot, "blobs", "sha256"), note: "shared by all namespaces"},
+		)
+	}
+
+	names := make([]string, 0, len(snapshotters))
+	for name := range snapshotters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		root := snapshotters[name]["root"]
+		if root == "" {
+			continue
+		}
+		if dir, ok := snapshotDirs[name]; ok {
+			root = filepath.Join(root, dir)
+		}
+
+		// The directories are shared; count what this namespace has there
+		note := ""
+		count := 0
+		err := app.client.SnapshotService(name).Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+			count++
+			return nil
+		})
+		if err == nil {
+			note = fmt.Sprintf("%d snapshots of %s", count, namespace)
+		}
+		paths = append(paths, diskPath{name: "Snapshotter " + name, path: root, note: note})
+	}
+	return paths, nil
+}
+
+// showDiskPaths shows the containerd directories on disk, so what
+// lazyctr shows can be correlated with du. Enter copies a path.
+func (app *App) showDiskPaths() {
+	namespace := app.currentNamespace
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	paths, err := app.diskPaths(ctx, namespace)
+	if err != nil {
+		app.showError(fmt.Sprintf("Cannot read paths, introspection is unavailable: %v", err))
+		return
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"Data", "Path", "Note"} {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+	for i, path := range paths {
+		table.SetCell(i+1, 0, tview.NewTableCell(path.name).SetTextColor(tcell.ColorTeal))
+		table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(path.path)).SetTextColor(tcell.ColorWhite).SetExpansion(1))
+		table.SetCell(i+1, 2, tview.NewTableCell(path.note).SetTextColor(tcell.ColorGray))
+	}
+	if len(paths) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell("The daemon exports no paths").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+	}
+
+	closePaths := func() {
+		app.closeDialog("paths")
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closePaths()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row > 0 && row <= len(paths) {
+				app.copyToClipboard(paths[row-1].path)
+				app.updateStatus(fmt.Sprintf("[green]Copied:[white] %s", tview.Escape(paths[row-1].path)))
+			}
+			return nil
+		}
+		return event
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Paths on disk, namespace %s (Enter: copy, Esc: close) ", namespace)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 6, true).
+			AddItem(nil, 0, 1, false), min(len(paths)+4, 20), 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("paths", modal, true, true)
+	app.tviewApp.SetFocus(table)
+}
@@ -0,0 +1,496 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/image-spec/identity"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+// unusedSnapshot is a prune candidate with its depth in the parent chain,
+// so children can be removed before their parents.
+type unusedSnapshot struct {
+	SnapshotInfo
+	depth int
+}
+
+// findUnusedSnapshots returns the snapshots that no container uses as its
+// root filesystem and that are not part of any image's unpacked layer
+// chain, directly or as a parent of such a snapshot. Snapshots held by a
+// lease or carrying containerd.io/gc.* labels are always kept, since the
+// garbage collector keeps them for a client, e.g. during a pull.
+func (app *App) findUnusedSnapshots(ctx context.Context, snapshotterNames []string) ([]unusedSnapshot, error) {
+	// Keys in use per snapshotter; image chains are checked in all of them
+	containerKeys := make(map[string]map[string]bool)
+	leasedKeys := make(map[string]map[string]bool)
+
+	containerList, err := app.client.ContainerService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	for _, c := range containerList {
+		if c.SnapshotKey == "" {
+			continue
+		}
+		if containerKeys[c.Snapshotter] == nil {
+			containerKeys[c.Snapshotter] = make(map[string]bool)
+		}
+		containerKeys[c.Snapshotter][c.SnapshotKey] = true
+	}
+
+	leaseService := app.client.LeasesService()
+	leaseList, err := leaseService.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leases: %w", err)
+	}
+	for _, lease := range leaseList {
+		resources, err := leaseService.ListResources(ctx, lease)
+		if err != nil {
+			return nil, fmt.Errorf("lease %s: %w", lease.ID, err)
+		}
+		for _, resource := range resources {
+			snapshotter, ok := strings.CutPrefix(resource.Type, "snapshots/")
+			if !ok {
+				continue
+			}
+			if leasedKeys[snapshotter] == nil {
+				leasedKeys[snapshotter] = make(map[string]bool)
+			}
+			leasedKeys[snapshotter][resource.ID] = true
+		}
+	}
+
+	imageList, err := app.client.ImageService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	imageKeys, err := app.imageSnapshotKeys(ctx, imageList)
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []unusedSnapshot
+	for _, name := range snapshotterNames {
+		all := make(map[string]snapshots.Info)
+		err := app.client.SnapshotService(name).Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+			all[info.Name] = info
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		// Everything a used snapshot is built on is used as well
+		used := make(map[string]bool)
+		for key, info := range all {
+			if !containerKeys[name][key] && !leasedKeys[name][key] && !imageKeys[key] && !hasGCLabel(info.Labels) {
+				continue
+			}
+			for parent := key; parent != "" && !used[parent]; parent = all[parent].Parent {
+				used[parent] = true
+			}
+		}
+
+		for key, info := range all {
+			if used[key] {
+				continue
+			}
+			depth := 0
+			for parent := info.Parent; parent != ""; parent = all[parent].Parent {
+				depth++
+			}
+			unused = append(unused, unusedSnapshot{
+				SnapshotInfo: SnapshotInfo{Key: key, Parent: info.Parent, Kind: info.Kind.String(), Snapshotter: name},
+				depth:        depth,
+			})
+		}
+	}
+
+	// Deepest first, so no snapshot is removed while it still has children
+	slices.SortFunc(unused, func(a, b unusedSnapshot) int {
+		return b.depth - a.depth
+	})
+
+	return unused, nil
+}
+
+// shownSnapshotters returns the snapshotters the Snapshots view covers.
+func (app *App) shownSnapshotters() []string {
+	if app.allSnapshotters {
+		return app.snapshotters
+	}
+	return []string{app.snapshotter}
+}
+
+// imageSnapshotKeys returns the snapshot keys images unpack to, for every
+// platform whose config is present rather than just the default one, as
+// images pulled for several platforms or run emulated unpack others too.
+func (app *App) imageSnapshotKeys(ctx context.Context, imageList []images.Image) (map[string]bool, error) {
+	contentStore := app.client.ContentStore()
+	keys := make(map[string]bool)
+
+	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		switch desc.MediaType {
+		case images.MediaTypeDockerSchema2Config, ocispec.MediaTypeImageConfig:
+			p, err := content.ReadBlob(ctx, contentStore, desc)
+			if errdefs.IsNotFound(err) {
+				return nil, nil
+			} else if err != nil {
+				return nil, err
+			}
+
+			var config ocispec.Image
+			if err := json.Unmarshal(p, &config); err != nil {
+				return nil, fmt.Errorf("config %s: %w", desc.Digest, err)
+			}
+			for _, chainID := range identity.ChainIDs(config.RootFS.DiffIDs) {
+				keys[chainID.String()] = true
+			}
+			return nil, nil
+		}
+
+		children, err := images.Children(ctx, contentStore, desc)
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return children, err
+	})
+
+	for _, img := range imageList {
+		if err := images.Walk(ctx, handler, img.Target); err != nil {
+			return nil, fmt.Errorf("%s: %w", img.Name, err)
+		}
+	}
+	return keys, nil
+}
+
+// hasGCLabel reports whether labels carry a containerd.io/gc.* label, by
+// which clients ask the garbage collector to keep a resource or what it
+// refers to.
+func hasGCLabel(labels map[string]string) bool {
+	for key := range labels {
+		if strings.HasPrefix(key, "containerd.io/gc.") {
+			return true
+		}
+	}
+	return false
+}
+
+// imageChainIDs returns the snapshot keys an image unpacks to. They must
+// be read before the image is deleted, while its content still exists.
+func (app *App) imageChainIDs(ctx context.Context, name string) map[string]bool {
+	img, err := app.client.ImageService().Get(ctx, name)
+	if err != nil {
+		return nil
+	}
+
+	chain, err := app.imageSnapshotKeys(ctx, []images.Image{img})
+	if err != nil {
+		return nil
+	}
+	return chain
+}
+
+// offerImageSnapshotCleanup offers to remove the snapshots of a deleted
+// image's layer chain that nothing uses anymore. They are looked for in
+// the background and the offer pops up once they are found.
+func (app *App) offerImageSnapshotCleanup(name string, chain map[string]bool) {
+	if len(chain) == 0 {
+		return
+	}
+
+	// The image may have been unpacked under several snapshotters, not
+	// just the one shown. Each is checked on its own so one that fails to
+	// answer doesn't hide the leftovers of the others.
+	names := app.snapshotters
+	if names == nil {
+		names = app.shownSnapshotters()
+	}
+	namespace := app.currentNamespace
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		ctx := namespaces.WithNamespace(context.Background(), namespace)
+		var leftover []unusedSnapshot
+		var under []string
+		for _, snapshotter := range names {
+			unused, err := app.findUnusedSnapshots(ctx, []string{snapshotter})
+			if err != nil {
+				continue
+			}
+			found := false
+			for _, snapshot := range unused {
+				if chain[snapshot.Key] {
+					leftover = append(leftover, snapshot)
+					found = true
+				}
+			}
+			if found {
+				under = append(under, snapshotter)
+			}
+		}
+		if len(leftover) == 0 {
+			return
+		}
+		size := app.snapshotsSize(ctx, leftover)
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			// Don't offer it over another namespace or another dialog
+			if app.currentNamespace != namespace {
+				return
+			}
+			if front, _ := app.pages.GetFrontPage(); front != "main" {
+				return
+			}
+			app.confirmPruneSnapshots(namespace, leftover, size, fmt.Sprintf("Also remove the snapshots of %s?", name),
+				fmt.Sprintf("under %s were unpacked from it and are used by nothing else", strings.Join(under, ", ")))
+		})
+	}()
+}
+
+func (app *App) pruneSnapshots() {
+	namespace := app.currentNamespace
+	snapshotters := app.shownSnapshotters()
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		ctx := namespaces.WithNamespace(context.Background(), namespace)
+		unused, err := app.findUnusedSnapshots(ctx, snapshotters)
+		var size int64
+		if err == nil {
+			size = app.snapshotsSize(ctx, unused)
+		}
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			switch {
+			case namespace != app.currentNamespace:
+			case err != nil:
+				app.showError(fmt.Sprintf("Failed to find unused snapshots: %v", err))
+			case len(unused) == 0:
+				app.updateStatus("[green]No unused snapshots found")
+			default:
+				app.confirmPruneSnapshots(namespace, unused, size, fmt.Sprintf("Prune unused snapshots in namespace '%s'?", namespace),
+					"are used by no container and no image")
+			}
+		})
+	}()
+}
+
+// snapshotsSize returns the disk space snapshots take, asking their
+// snapshotters.
+func (app *App) snapshotsSize(ctx context.Context, unused []unusedSnapshot) int64 {
+	var size int64
+	for _, snapshot := range unused {
+		size += app.itemSize(ctx, snapshot.SnapshotInfo)
+	}
+	return size
+}
+
+// confirmPruneSnapshots asks before removing snapshots of a namespace,
+// showing how much space they take.
+func (app *App) confirmPruneSnapshots(namespace string, unused []unusedSnapshot, size int64, question, reason string) {
+	stopCountdown := func() {}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s\n\n%d snapshots (%s) %s.\nThis action cannot be undone!",
+			question, len(unused), formatSize(size), reason)).
+		AddButtons([]string{"Prune", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-prune")
+			if buttonLabel == "Prune" {
+				app.performPruneSnapshots(namespace, unused)
+			}
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Prune Snapshots ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-prune", modal, true, true)
+	if app.countdownAll {
+		stopCountdown = app.startConfirmCountdown(modal, []string{"Prune", "Cancel"}, app.deleteCountdown)
+	}
+}
+
+func (app *App) performPruneSnapshots(namespace string, unused []unusedSnapshot) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	app.updateStatus(fmt.Sprintf("[yellow]Pruning %d snapshots...", len(unused)))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		successCount := 0
+		failCount := 0
+		var pruned []string
+		var freed int64
+
+		for _, snapshot := range unused {
+			size := app.itemSize(ctx, snapshot.SnapshotInfo)
+			err := app.client.SnapshotService(snapshot.Snapshotter).Remove(ctx, snapshot.Key)
+			if err != nil && !errdefs.IsNotFound(err) {
+				failCount++
+				continue
+			}
+			pruned = append(pruned, itemID(snapshot.SnapshotInfo))
+			successCount++
+			freed += size
+		}
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			for _, id := range pruned {
+				app.recordDeletion(namespace, ResourceSnapshots.String(), id)
+			}
+
+			if failCount > 0 {
+				app.updateStatus(fmt.Sprintf("[yellow]Pruned %d snapshots, %d failed%s", successCount, failCount, freedNote(freed)))
+			} else {
+				app.updateStatus(fmt.Sprintf("[green]Pruned %d snapshots%s", successCount, freedNote(freed)))
+			}
+			if namespace == app.currentNamespace {
+				app.loadItems()
+			}
+		})
+	}()
+}
+
+// snapshotReferences lists what points at a snapshot: snapshots based on
+// it, containers whose root filesystem is it or is built on it, and
+// images whose unpacked layer chain includes it.
+func (app *App) snapshotReferences(ctx context.Context, snapshot SnapshotInfo) (children, containerIDs, imageNames []string, err error) {
+	all := make(map[string]snapshots.Info)
+	err = app.client.SnapshotService(snapshot.Snapshotter).Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+		all[info.Name] = info
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for key, info := range all {
+		if info.Parent == snapshot.Key {
+			children = append(children, key)
+		}
+	}
+
+	containerList, err := app.client.ContainerService().List(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, c := range containerList {
+		if c.Snapshotter != snapshot.Snapshotter || c.SnapshotKey == "" {
+			continue
+		}
+		for key := c.SnapshotKey; key != ""; key = all[key].Parent {
+			if key == snapshot.Key {
+				containerIDs = append(containerIDs, c.ID)
+				break
+			}
+		}
+	}
+
+	imageList, err := app.client.ImageService().List(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, img := range imageList {
+		keys, err := app.imageSnapshotKeys(ctx, []images.Image{img})
+		if err != nil {
+			continue
+		}
+		if keys[snapshot.Key] {
+			imageNames = append(imageNames, img.Name)
+		}
+	}
+
+	slices.Sort(children)
+	slices.Sort(containerIDs)
+	slices.Sort(imageNames)
+	return children, containerIDs, imageNames, nil
+}
+
+func (app *App) showSnapshotReferences(snapshot SnapshotInfo) {
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+
+	header := fmt.Sprintf("[yellow]Snapshot:[white] %s\n[yellow]Snapshotter:[white] %s  [yellow]Kind:[white] %s\n",
+		tview.Escape(snapshot.Key), snapshot.Snapshotter, snapshot.Kind)
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(header + "\n[gray]Walking snapshots, containers and images...[white]\n")
+
+	view.SetBorder(true).
+		SetTitle(" Snapshot References (Esc: close) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			app.closeDialog("references")
+		}
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(view, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("references", modal, true, true)
+	app.tviewApp.SetFocus(view)
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		children, containerIDs, imageNames, err := app.snapshotReferences(ctx, snapshot)
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			// Closed meanwhile
+			if front, _ := app.pages.GetFrontPage(); front != "references" {
+				return
+			}
+			if err != nil {
+				app.closeDialog("references")
+				app.showError(fmt.Sprintf("Failed to find references to %s: %v", snapshot.Key, err))
+				return
+			}
+
+			var text strings.Builder
+			text.WriteString(header)
+
+			sections := []struct {
+				title string
+				names []string
+			}{
+				{"Child snapshots", children},
+				{"Containers (directly or through children)", containerIDs},
+				{"Images whose layer chain includes it", imageNames},
+			}
+			for _, section := range sections {
+				fmt.Fprintf(&text, "\n[yellow]%s: %d[white]\n", section.title, len(section.names))
+				for _, name := range section.names {
+					fmt.Fprintf(&text, "  %s\n", tview.Escape(name))
+				}
+			}
+
+			if len(children)+len(containerIDs)+len(imageNames) == 0 {
+				text.WriteString("\n[green]Nothing references this snapshot; it is safe to remove.[white]\n")
+			}
+			view.SetText(text.String())
+		})
+	}()
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+func (app *App) pullImage() {
+	refInput := tview.NewInputField().
+		SetLabel("Reference: ").
+		SetFieldWidth(50)
+
+	platformInput := tview.NewInputField().
+		SetLabel("Platform:  ").
+		SetFieldWidth(50).
+		SetPlaceholder(platforms.DefaultString())
+
+	ifMissing := tview.NewCheckbox().
+		SetLabel("Only if missing: ")
+
+	closeDialog := func() {
+		app.closeDialog("pull")
+	}
+
+	submit := func() {
+		closeDialog()
+
+		ref := strings.TrimSpace(refInput.GetText())
+		if ref == "" {
+			return
+		}
+
+		platform, err := parsePlatform(platformInput.GetText())
+		if err != nil {
+			app.showError(fmt.Sprintf("Invalid platform %q: %v", platformInput.GetText(), err))
+			return
+		}
+
+		namespace := app.currentNamespace
+		app.confirmLowSpacePull(namespace, ref, platform, func() {
+			app.startPull(namespace, ref, platform, ifMissing.IsChecked(), nil)
+		})
+	}
+
+	for _, input := range []*tview.InputField{refInput, platformInput} {
+		input.SetDoneFunc(func(key tcell.Key) {
+			if key == tcell.KeyEnter {
+				submit()
+			} else if key == tcell.KeyEscape {
+				closeDialog()
+			}
+		})
+	}
+
+	ifMissing.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			closeDialog()
+		}
+	})
+
+	form := tview.NewForm().
+		AddFormItem(refInput).
+		AddFormItem(platformInput).
+		AddFormItem(ifMissing)
+
+	free := ""
+	if space, err := app.dataRootSpace(); err == nil {
+		free = fmt.Sprintf(" (%s free)", formatSize(space.free))
+	}
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Pull Image [%s]%s ", app.currentNamespace, free)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 70, 1, true).
+			AddItem(nil, 0, 1, false), 9, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("pull", modal, true, true)
+	app.tviewApp.SetFocus(refInput)
+}
+
+// startPull pulls an image in the background, with creds or else the
+// credentials stored in the Docker config. When the registry refuses the
+// pull for lack of authorization, it prompts for credentials and retries
+// with them. With ifMissing, images already present for the platform are
+// not pulled again.
+func (app *App) startPull(namespace, ref string, platform ocispec.Platform, ifMissing bool, creds *registryCredentials) {
+	app.updateStatus(fmt.Sprintf("[yellow]Pulling:[white] %s (%s)...", ref, platforms.Format(platform)))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		name, pulled, err := app.performPull(namespace, ref, platform, ifMissing, creds)
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				if isUnauthorized(err) {
+					app.promptRegistryCredentials("pull", ref, creds, err, func(creds *registryCredentials) {
+						app.startPull(namespace, ref, platform, ifMissing, creds)
+					})
+					return
+				}
+				app.showError(fmt.Sprintf("Failed to pull %s: %v", ref, err))
+				return
+			}
+
+			if !pulled {
+				app.updateStatus(fmt.Sprintf("[green]Already present:[white] %s (%s), not pulled", name, platforms.Format(platform)))
+				return
+			}
+
+			app.contentChanged(namespace)
+			app.updateStatus(fmt.Sprintf("[green]Pulled:[white] %s (%s)", name, platforms.Format(platform)))
+			if namespace == app.currentNamespace && app.currentResource == ResourceImages {
+				app.loadItems()
+			}
+		})
+	}()
+}
+
+// parsePlatform validates a platform specifier, defaulting to the host
+// platform when it is empty.
+func parsePlatform(specifier string) (ocispec.Platform, error) {
+	specifier = strings.TrimSpace(specifier)
+	if specifier == "" {
+		return platforms.DefaultSpec(), nil
+	}
+
+	platform, err := platforms.Parse(specifier)
+	if err != nil {
+		return ocispec.Platform{}, err
+	}
+	return platforms.Normalize(platform), nil
+}
+
+// performPull pulls ref for platform and reports whether it did. With
+// ifMissing, the pull is skipped when the image exists and all of its
+// content for the platform is present.
+func (app *App) performPull(namespace, ref string, platform ocispec.Platform, ifMissing bool, creds *registryCredentials) (string, bool, error) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	named, err := reference.ParseDockerRef(ref)
+	if err != nil {
+		return "", false, err
+	}
+
+	if ifMissing {
+		present, err := app.imagePresent(ctx, named.String(), platform)
+		if err != nil {
+			return "", false, err
+		}
+		if present {
+			return named.String(), false, nil
+		}
+	}
+
+	// Only unpack images that can run here; foreign platforms are
+	// typically pulled for export and would just waste snapshot space.
+	unpack := platforms.Default().Match(platform)
+
+	if app.transferService {
+		if err := app.performTransferPull(ctx, named.String(), platform, unpack, creds); err != nil {
+			return "", false, err
+		}
+		return named.String(), true, nil
+	}
+
+	opts := []containerd.RemoteOpt{
+		containerd.WithPlatform(platforms.Format(platform)),
+		containerd.WithPullSnapshotter(app.snapshotter),
+		containerd.WithResolver(app.newResolver(creds)),
+	}
+	if unpack {
+		opts = append(opts, containerd.WithPullUnpack)
+	}
+
+	img, err := app.client.Pull(ctx, named.String(), opts...)
+	if err != nil {
+		return "", false, err
+	}
+
+	return img.Name(), true, nil
+}
+
+// imagePresent reports whether an image exists with all of its content for
+// platform in the content store.
+func (app *App) imagePresent(ctx context.Context, name string, platform ocispec.Platform) (bool, error) {
+	img, err := app.client.GetImage(ctx, name)
+	if errdefs.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	available, _, _, missing, err := images.Check(ctx, app.client.ContentStore(), img.Target(), platforms.Only(platform))
+	if err != nil {
+		return false, err
+	}
+	return available && len(missing) == 0, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+func (app *App) pushImage() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("push")
+		return
+	}
+
+	img, ok := item.(ImageInfo)
+	if !ok {
+		return
+	}
+
+	refInput := tview.NewInputField().
+		SetLabel("Push to: ").
+		SetFieldWidth(60).
+		SetText(img.Name)
+
+	refInput.SetDoneFunc(func(key tcell.Key) {
+		ref := strings.TrimSpace(refInput.GetText())
+		app.closeDialog("push")
+
+		if key != tcell.KeyEnter || ref == "" {
+			return
+		}
+
+		named, err := reference.ParseDockerRef(ref)
+		if err != nil {
+			app.showError(fmt.Sprintf("Invalid reference %q: %v", ref, err))
+			return
+		}
+
+		app.startPush(app.currentNamespace, img, named.String(), nil)
+	})
+
+	form := tview.NewForm().
+		AddFormItem(refInput)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Push %s ", img.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("push", modal, true, true)
+	app.tviewApp.SetFocus(refInput)
+}
+
+// startPush pushes an image in the background, with creds or else the
+// credentials stored in the Docker config. When the registry refuses the
+// push for lack of authorization, it prompts for credentials and retries
+// with them.
+func (app *App) startPush(namespace string, img ImageInfo, ref string, creds *registryCredentials) {
+	app.updateStatus(fmt.Sprintf("[yellow]Pushing:[white] %s → %s...", img.Name, ref))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		err := app.performPush(namespace, img, ref, creds)
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				if isUnauthorized(err) {
+					app.promptRegistryCredentials("push", ref, creds, err, func(creds *registryCredentials) {
+						app.startPush(namespace, img, ref, creds)
+					})
+					return
+				}
+				app.showError(fmt.Sprintf("Failed to push %s to %s: %v", img.Name, ref, err))
+				return
+			}
+			app.updateStatus(fmt.Sprintf("[green]Pushed:[white] %s → %s", img.Name, ref))
+		})
+	}()
+}
+
+// performPush uploads the content of an image to ref, reporting the config
+// and layer blobs uploaded so far in the status bar; manifests follow once
+// their blobs are in. Every platform the image lists is
+// pushed, so multi-platform images must be complete locally.
+func (app *App) performPush(namespace string, img ImageInfo, ref string, creds *registryCredentials) error {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	var blobs, uploaded atomic.Int64
+	countUploads := func(h images.Handler) images.Handler {
+		return images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			children, err := h.Handle(ctx, desc)
+			if err == nil {
+				blobs.Add(1)
+				uploaded.Add(desc.Size)
+			}
+			return children, err
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				n, size := blobs.Load(), uploaded.Load()
+				app.tviewApp.QueueUpdateDraw(func() {
+					app.updateStatus(fmt.Sprintf("[yellow]Pushing:[white] %s → %s, %d blobs (%s) uploaded", img.Name, ref, n, formatSize(size)))
+				})
+			}
+		}
+	}()
+
+	err := app.client.Push(ctx, ref, img.Target,
+		containerd.WithResolver(app.newResolver(creds)),
+		containerd.WithImageHandlerWrapper(countUploads))
+	close(done)
+	return err
+}
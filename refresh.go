@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/containerd/containerd/namespaces"
+)
+
+// refreshIntervals are the auto-refresh steps selectable with + and -.
+// Zero means auto-refresh is off. The shortest step keeps lazyctr from
+// hammering containerd.
+var refreshIntervals = []time.Duration{
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	0,
+}
+
+// startAutoRefresh reloads the items panel every refresh interval. The
+// timer is rearmed whenever the interval changes.
+func (app *App) startAutoRefresh() {
+	go func() {
+		for {
+			interval := app.currentRefreshInterval()
+			if interval == 0 {
+				<-app.refreshReset
+				continue
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-timer.C:
+				app.tviewApp.QueueUpdateDraw(app.refreshItems)
+			case <-app.refreshReset:
+				timer.Stop()
+			}
+		}
+	}()
+}
+
+func (app *App) currentRefreshInterval() time.Duration {
+	app.refreshMu.Lock()
+	defer app.refreshMu.Unlock()
+	return app.refreshInterval
+}
+
+// adjustRefreshInterval moves the refresh interval steps shorter (-1) or
+// longer (+1). Past the longest step auto-refresh turns off.
+func (app *App) adjustRefreshInterval(step int) {
+	app.refreshMu.Lock()
+	index := slices.Index(refreshIntervals, app.refreshInterval)
+	next := min(max(index+step, 0), len(refreshIntervals)-1)
+	app.refreshInterval = refreshIntervals[next]
+	interval := app.refreshInterval
+	app.refreshMu.Unlock()
+
+	select {
+	case app.refreshReset <- struct{}{}:
+	default:
+	}
+
+	app.config.RefreshInterval = int(interval / time.Second)
+	app.saveSetting("Auto-refresh", refreshText(interval))
+}
+
+func refreshText(interval time.Duration) string {
+	if interval == 0 {
+		return "off"
+	}
+	return "every " + interval.String()
+}
+
+// refreshItems reloads the current view in the background, keeping the
+// search filter, marks and selection. Refreshes are skipped while a dialog
+// is open or a previous refresh is still running.
+func (app *App) refreshItems() {
+	if app.refreshing || app.currentNamespace == "" {
+		return
+	}
+	if name, _ := app.pages.GetFrontPage(); name != "main" {
+		return
+	}
+
+	namespace, resource := app.currentNamespace, app.currentResource
+	filters := app.contentWalkFilters()
+	unusedOnly := app.unusedImageFilter()
+	app.refreshing = true
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+		var items []interface{}
+		var err error
+		switch {
+		case len(filters) > 0:
+			items, err = app.loadContent(ctx, filters...)
+		case resource == ResourceImages:
+			items, err = app.listImages(ctx)
+		default:
+			items, err = app.fetchItems(ctx, resource)
+		}
+
+		var used map[string]bool
+		if err == nil && unusedOnly {
+			used, err = app.containerImages(ctx)
+		}
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.refreshing = false
+			if err != nil || namespace != app.currentNamespace || resource != app.currentResource {
+				return
+			}
+			if used != nil {
+				app.usedImages = used
+			}
+
+			index := app.selectedIndex()
+			var selected string
+			if index >= 0 {
+				selected = itemID(app.itemCache[index])
+			}
+			atBottom := index == len(app.itemCache)-1
+
+			app.highlightChanges(app.allItems, items)
+			app.allItems = items
+			app.scanImageSizes()
+			app.filterItems()
+
+			switch {
+			case app.followTail && atBottom:
+				app.selectIndex(len(app.itemCache) - 1)
+			case app.config.SelectFirst:
+				// Start over on the first row, as the render left it
+			case selected != "" && !app.selectItem(selected) && index < len(app.itemCache):
+				// The selected item is gone; stay at the same position
+				app.selectIndex(index)
+			}
+		})
+	}()
+}
+
+// toggleFollowTail turns following the newest items on or off. While on,
+// refreshes keep the selection on the last row as items are added, unless
+// the selection was moved up from it.
+func (app *App) toggleFollowTail() {
+	app.followTail = !app.followTail
+	if app.followTail {
+		app.selectIndex(len(app.itemCache) - 1)
+		app.updateStatus("Follow: [green]on[white] (selection stays on the newest item)")
+		return
+	}
+	app.updateStatus("Follow: [green]off[white]")
+}
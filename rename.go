@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/identifiers"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+// namespaceMigration summarizes what a rename moves.
+type namespaceMigration struct {
+	images int
+	blobs  int
+	size   int64
+}
+
+func (app *App) renameNamespace() {
+	if app.currentNamespace == "" {
+		return
+	}
+	oldName := app.currentNamespace
+
+	nameInput := tview.NewInputField().
+		SetLabel("New name: ").
+		SetFieldWidth(40).
+		SetText(oldName)
+
+	nameInput.SetDoneFunc(func(key tcell.Key) {
+		newName := strings.TrimSpace(nameInput.GetText())
+		app.closeDialog("rename-ns")
+
+		if key != tcell.KeyEnter || newName == "" || newName == oldName {
+			return
+		}
+		if err := identifiers.Validate(newName); err != nil {
+			app.showError(fmt.Sprintf("Invalid namespace name: %v", err))
+			return
+		}
+
+		migration, err := app.planNamespaceMigration(oldName, newName)
+		if err != nil {
+			app.showError(fmt.Sprintf("Cannot rename %s: %v", oldName, err))
+			return
+		}
+		app.confirmRenameNamespace(oldName, newName, migration)
+	})
+
+	form := tview.NewForm().
+		AddFormItem(nameInput)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Rename Namespace %s ", oldName)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 60, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("rename-ns", modal, true, true)
+	app.tviewApp.SetFocus(nameInput)
+}
+
+// planNamespaceMigration checks that a namespace can be renamed and counts
+// what would move. containerd has no rename, so the namespace is recreated
+// under the new name. Containers are bound to their snapshots and tasks
+// and cannot be moved, so namespaces that have any are refused.
+func (app *App) planNamespaceMigration(oldName, newName string) (namespaceMigration, error) {
+	ctx := namespaces.WithNamespace(context.Background(), oldName)
+
+	existing, err := app.client.NamespaceService().List(context.Background())
+	if err != nil {
+		return namespaceMigration{}, err
+	}
+	if slices.Contains(existing, newName) {
+		return namespaceMigration{}, fmt.Errorf("namespace %s already exists", newName)
+	}
+
+	var migration namespaceMigration
+
+	containerList, err := app.client.ContainerService().List(ctx)
+	if err != nil {
+		return migration, err
+	}
+	if len(containerList) > 0 {
+		return migration, fmt.Errorf("it has %d containers, which cannot be moved to another namespace; delete them first", len(containerList))
+	}
+
+	imageList, err := app.client.ImageService().List(ctx)
+	if err != nil {
+		return migration, err
+	}
+	migration.images = len(imageList)
+
+	err = app.client.ContentStore().Walk(ctx, func(info content.Info) error {
+		migration.blobs++
+		migration.size += info.Size
+		return nil
+	})
+	return migration, err
+}
+
+func (app *App) confirmRenameNamespace(oldName, newName string, migration namespaceMigration) {
+	stopCountdown := func() {}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Rename namespace '%s' to '%s'?\n\n"+
+			"Moves %d images and %d content blobs (%s), then deletes '%s' with everything left in it.\n"+
+			"Snapshots are not moved; images are unpacked again when next used.",
+			oldName, newName, migration.images, migration.blobs, formatSize(migration.size), oldName)).
+		AddButtons([]string{"Rename", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			stopCountdown()
+			app.closeDialog("confirm-rename")
+			if buttonLabel != "Rename" {
+				return
+			}
+
+			app.updateStatus(fmt.Sprintf("[yellow]Moving namespace:[white] %s → %s", oldName, newName))
+
+			// Run the blocking operation in a goroutine to prevent UI freeze
+			go func() {
+				err := app.performMigrateNamespace(oldName, newName)
+				var deleteErr error
+				if err == nil {
+					deleteErr = app.removeNamespace(oldName)
+				}
+				// Queue UI updates on the main thread
+				app.tviewApp.QueueUpdateDraw(func() {
+					app.contentChanged(newName)
+					if err != nil {
+						app.reloadNamespaces()
+						app.showError(fmt.Sprintf("Failed to move %s to %s, '%s' was left untouched: %v", oldName, newName, oldName, err))
+						return
+					}
+
+					app.contentChanged(oldName)
+					app.reloadNamespaces()
+					app.selectNamespace(newName)
+					if deleteErr != nil {
+						app.updateStatus(fmt.Sprintf("[yellow]Copied namespace:[white] %s → %s, but '%s' was not deleted", oldName, newName, oldName))
+						app.showError(fmt.Sprintf("Copied %s to %s, but deleting %s failed: %v\n\nBoth namespaces now hold the images; delete '%s' with D once the cause is fixed.",
+							oldName, newName, oldName, deleteErr, oldName))
+						return
+					}
+					app.recordDeletion(oldName, "Namespace", oldName)
+					app.updateStatus(fmt.Sprintf("[green]Renamed namespace:[white] %s → %s", oldName, newName))
+				})
+			}()
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Rename Namespace ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-rename", modal, true, true)
+	// A rename deletes the old namespace, so it shares the namespace delete
+	// countdown (--delete-countdown)
+	stopCountdown = app.startConfirmCountdown(modal, []string{"Rename", "Cancel"}, app.deleteCountdown)
+}
+
+// performMigrateNamespace creates the new namespace with the labels of the
+// old one and copies all content, with its labels, and all images into it.
+// Content goes first so the images never reference missing blobs. If a
+// copy fails, what was copied is removed again along with the new
+// namespace, and the error says whether that worked.
+func (app *App) performMigrateNamespace(oldName, newName string) error {
+	srcCtx := namespaces.WithNamespace(context.Background(), oldName)
+	dstCtx := namespaces.WithNamespace(context.Background(), newName)
+
+	namespaceService := app.client.NamespaceService()
+	labels, err := namespaceService.Labels(context.Background(), oldName)
+	if err != nil {
+		return err
+	}
+	if err := namespaceService.Create(context.Background(), newName, labels); err != nil {
+		return err
+	}
+
+	var copiedImages []string
+	var copiedBlobs []digest.Digest
+	rollback := func(err error) error {
+		if rollbackErr := app.rollbackMigration(dstCtx, newName, copiedImages, copiedBlobs); rollbackErr != nil {
+			return fmt.Errorf("%w\n\nRemoving namespace '%s' again failed, delete it by hand: %v", err, newName, rollbackErr)
+		}
+		return fmt.Errorf("%w\n\nRemoved the %d images and %d blobs already copied and namespace '%s' again",
+			err, len(copiedImages), len(copiedBlobs), newName)
+	}
+
+	contentStore := app.client.ContentStore()
+	var blobs []content.Info
+	if err := contentStore.Walk(srcCtx, func(info content.Info) error {
+		blobs = append(blobs, info)
+		return nil
+	}); err != nil {
+		return rollback(err)
+	}
+
+	for _, info := range blobs {
+		desc := ocispec.Descriptor{Digest: info.Digest, Size: info.Size}
+		if err := copyBlob(srcCtx, dstCtx, contentStore, desc, info.Labels); err != nil {
+			// Drop the half written copy, it would keep the namespace busy
+			contentStore.Abort(dstCtx, "lazyctr-copy-"+info.Digest.Encoded())
+			return rollback(fmt.Errorf("%s: %w", info.Digest, err))
+		}
+		copiedBlobs = append(copiedBlobs, info.Digest)
+	}
+
+	imageService := app.client.ImageService()
+	imageList, err := imageService.List(srcCtx)
+	if err != nil {
+		return rollback(err)
+	}
+	for _, img := range imageList {
+		if _, err := imageService.Create(dstCtx, img); err != nil {
+			return rollback(fmt.Errorf("%s: %w", img.Name, err))
+		}
+		copiedImages = append(copiedImages, img.Name)
+	}
+
+	return nil
+}
+
+// rollbackMigration removes what a failed migration copied into the new
+// namespace, images first so none points at deleted content, then the
+// namespace itself. Items already gone are fine.
+func (app *App) rollbackMigration(ctx context.Context, newName string, copiedImages []string, copiedBlobs []digest.Digest) error {
+	imageService := app.client.ImageService()
+	for _, name := range copiedImages {
+		if err := imageService.Delete(ctx, name); err != nil && !errdefs.IsNotFound(err) {
+			return fmt.Errorf("image %s: %w", name, err)
+		}
+	}
+
+	contentStore := app.client.ContentStore()
+	for _, dgst := range copiedBlobs {
+		if err := contentStore.Delete(ctx, dgst); err != nil && !errdefs.IsNotFound(err) {
+			return fmt.Errorf("blob %s: %w", dgst, err)
+		}
+	}
+
+	if err := app.client.NamespaceService().Delete(context.Background(), newName); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// selectNamespace selects a namespace in the namespace list, reloading the
+// list first if it isn't shown yet.
+func (app *App) selectNamespace(name string) {
+	for pass := 0; pass < 2; pass++ {
+		for i := 0; i < app.namespaceList.GetItemCount(); i++ {
+			if text, _ := app.namespaceList.GetItemText(i); text == name {
+				app.namespaceList.SetCurrentItem(i)
+				return
+			}
+		}
+		app.reloadNamespaces()
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// restartStopTimeout is how long a task gets to exit on SIGTERM before it
+// is killed.
+const restartStopTimeout = 10 * time.Second
+
+// criNamespace is the namespace the CRI plugin keeps Kubernetes pods in.
+const criNamespace = "k8s.io"
+
+// errCRIManaged refuses to restart a container the kubelet manages: a new
+// task started behind its back has no network namespace or log pipes, and
+// the kubelet would just replace it.
+var errCRIManaged = errors.New("it is managed by Kubernetes; restart its pod through Kubernetes instead")
+
+func (app *App) restartContainer() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("restart")
+		return
+	}
+
+	id := containerIDOf(item)
+	if id == "" {
+		return
+	}
+	if task, ok := item.(TaskInfo); ok && task.Orphaned {
+		app.showError(fmt.Sprintf("Task %s has no container to restart it from", id))
+		return
+	}
+
+	namespace := app.currentNamespace
+	if container, ok := item.(ContainerInfo); namespace == criNamespace || (ok && container.SandboxID != "") {
+		app.showError(fmt.Sprintf("Cannot restart %s: %v", id, errCRIManaged))
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Restart container '%s' in namespace '%s'?\n\nIts task is stopped (SIGTERM, then SIGKILL after %s) and a new one is started.\nThe new task has no I/O attached.",
+			id, namespace, restartStopTimeout)).
+		AddButtons([]string{"Restart", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			app.closeDialog("confirm-restart")
+			if buttonLabel != "Restart" {
+				return
+			}
+
+			app.updateStatus(fmt.Sprintf("[yellow]Restarting:[white] %s...", id))
+
+			// Run the blocking operation in a goroutine to prevent UI freeze
+			go func() {
+				pid, err := app.performRestart(namespace, id)
+				// Queue UI updates on the main thread
+				app.tviewApp.QueueUpdateDraw(func() {
+					if err != nil {
+						app.showError(fmt.Sprintf("Failed to restart %s: %v", id, err))
+						return
+					}
+					if namespace == app.currentNamespace && (app.currentResource == ResourceContainers || app.currentResource == ResourceTasks) {
+						app.loadItems()
+					}
+					app.updateStatus(fmt.Sprintf("[green]Restarted:[white] %s (PID %d)", id, pid))
+				})
+			}()
+		})
+
+	modal.SetBorder(true).SetTitle(" ⚠ Confirm Restart ")
+	modal.SetBackgroundColor(tcell.ColorDefault)
+
+	app.pages.AddPage("confirm-restart", modal, true, true)
+}
+
+// performRestart stops and deletes the task of a container, if it has one,
+// then starts a new task from the same spec, detached like the tasks lazyctr
+// runs. It returns the PID of the new task.
+func (app *App) performRestart(namespace, id string) (uint32, error) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	container, err := app.client.LoadContainer(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Tasks are listed without their containers' metadata, so check here too
+	info, err := container.Info(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if namespace == criNamespace || info.SandboxID != "" {
+		return 0, errCRIManaged
+	}
+	if metadata, err := decodeCRIMetadata(info); err == nil && metadata.SandboxID != "" {
+		return 0, errCRIManaged
+	}
+
+	task, err := container.Task(ctx, nil)
+	switch {
+	case err == nil:
+		if err := stopTask(ctx, task); err != nil {
+			return 0, fmt.Errorf("failed to stop the task: %w", err)
+		}
+		if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil {
+			return 0, fmt.Errorf("failed to delete the old task: %w", err)
+		}
+	case !errdefs.IsNotFound(err):
+		return 0, err
+	}
+
+	task, err = container.NewTask(ctx, cio.NullIO)
+	if err != nil {
+		return 0, err
+	}
+	if err := task.Start(ctx); err != nil {
+		task.Delete(ctx)
+		return 0, err
+	}
+
+	return task.Pid(), nil
+}
+
+// stopTask ends a task gracefully: SIGTERM first, SIGKILL if it is still
+// running after restartStopTimeout. Paused tasks are resumed so they can
+// handle the signal.
+func stopTask(ctx context.Context, task containerd.Task) error {
+	status, err := task.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch status.Status {
+	case containerd.Stopped, containerd.Created:
+		// Nothing runs yet or anymore; deleting the task is enough
+		return nil
+	case containerd.Paused, containerd.Pausing:
+		if err := task.Resume(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Wait must be set up before signaling to not miss the exit
+	exited, err := task.Wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := task.Kill(ctx, syscall.SIGTERM); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(restartStopTimeout):
+	}
+
+	if err := task.Kill(ctx, syscall.SIGKILL, containerd.WithKillAll); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-time.After(restartStopTimeout):
+		return fmt.Errorf("task did not exit after SIGKILL")
+	}
+}
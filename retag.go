@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/distribution/reference"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// retagPlan is one image reference to rewrite. err is set when the new
+// reference is unusable; such entries are shown but skipped.
+type retagPlan struct {
+	from string
+	to   string
+	err  error
+}
+
+// bulkRetag asks for a match pattern and a replacement, e.g.
+// "olddockerhub.io/*" and "newregistry.io/*", to rewrite the references of
+// many images at once.
+func (app *App) bulkRetag() {
+	patternInput := tview.NewInputField().
+		SetLabel("Match:       ").
+		SetFieldWidth(50).
+		SetPlaceholder("olddockerhub.io/*")
+
+	replacementInput := tview.NewInputField().
+		SetLabel("Replace with: ").
+		SetFieldWidth(50).
+		SetPlaceholder("newregistry.io/*")
+
+	deleteOld := tview.NewCheckbox().
+		SetLabel("Delete old:  ")
+
+	closeDialog := func() {
+		app.closeDialog("retag")
+	}
+
+	preview := func() {
+		pattern := strings.TrimSpace(patternInput.GetText())
+		replacement := strings.TrimSpace(replacementInput.GetText())
+		if pattern == "" || replacement == "" {
+			return
+		}
+
+		plans, err := planRetag(app.allItems, pattern, replacement)
+		if err != nil {
+			app.showError(err.Error())
+			return
+		}
+		closeDialog()
+		if len(plans) == 0 {
+			app.updateStatus(fmt.Sprintf("[yellow]No images match:[white] %s", tview.Escape(pattern)))
+			return
+		}
+		app.confirmRetag(plans, deleteOld.IsChecked())
+	}
+
+	form := tview.NewForm().
+		AddFormItem(patternInput).
+		AddFormItem(replacementInput).
+		AddFormItem(deleteOld).
+		AddButton("Preview", preview).
+		AddButton("Cancel", closeDialog)
+
+	form.SetCancelFunc(closeDialog)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Retag Images [%s] (* matches anything) ", app.currentNamespace)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 72, 1, true).
+			AddItem(nil, 0, 1, false), 11, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("retag", modal, true, true)
+	app.tviewApp.SetFocus(form)
+}
+
+// planRetag matches every image against pattern, where each * matches any
+// text, and builds the new reference by substituting what the *s matched,
+// in order, for the *s of replacement. New references are validated like
+// pull references.
+func planRetag(items []interface{}, pattern, replacement string) ([]retagPlan, error) {
+	wildcards := strings.Count(pattern, "*")
+	if strings.Count(replacement, "*") > wildcards {
+		return nil, fmt.Errorf("replacement %q has more * than pattern %q", replacement, pattern)
+	}
+
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, "(.*)") + "$"
+	matcher, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, item := range items {
+		if img, ok := item.(ImageInfo); ok {
+			existing[img.Name] = true
+		}
+	}
+
+	var plans []retagPlan
+	planned := make(map[string]bool)
+	for _, item := range items {
+		img, ok := item.(ImageInfo)
+		if !ok {
+			continue
+		}
+		m := matcher.FindStringSubmatch(img.Name)
+		if m == nil {
+			continue
+		}
+
+		parts := strings.Split(replacement, "*")
+		var b strings.Builder
+		for i, part := range parts {
+			b.WriteString(part)
+			if i < len(parts)-1 {
+				b.WriteString(m[i+1])
+			}
+		}
+
+		plan := retagPlan{from: img.Name, to: b.String()}
+		if named, err := reference.ParseDockerRef(plan.to); err != nil {
+			plan.err = err
+		} else {
+			plan.to = named.String()
+			switch {
+			case existing[plan.to]:
+				plan.err = fmt.Errorf("already exists")
+			case planned[plan.to]:
+				plan.err = fmt.Errorf("duplicate")
+			}
+			planned[plan.to] = true
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// confirmRetag previews the rewritten references before creating them.
+func (app *App) confirmRetag(plans []retagPlan, deleteOld bool) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+
+	valid := 0
+	for _, plan := range plans {
+		if plan.err != nil {
+			fmt.Fprintf(view, "[gray]%s → %s [red](%s)[-]\n", tview.Escape(plan.from), tview.Escape(plan.to), tview.Escape(plan.err.Error()))
+			continue
+		}
+		valid++
+		fmt.Fprintf(view, "[white]%s [yellow]→[green] %s[-]\n", tview.Escape(plan.from), tview.Escape(plan.to))
+	}
+
+	action := "Create"
+	if deleteOld {
+		action = "Move"
+	}
+
+	closePreview := func() {
+		app.closeDialog("retag-preview")
+	}
+
+	buttons := tview.NewForm().
+		AddButton(fmt.Sprintf("%s %d", action, valid), func() {
+			closePreview()
+			if valid == 0 {
+				return
+			}
+			namespace := app.currentNamespace
+			app.updateStatus(fmt.Sprintf("[yellow]Retagging %d images...", valid))
+
+			// Run the blocking operation in a goroutine to prevent UI freeze
+			go func() {
+				created, deleted, failures := app.performRetag(namespace, plans, deleteOld)
+				// Queue UI updates on the main thread
+				app.tviewApp.QueueUpdateDraw(func() {
+					for _, from := range deleted {
+						app.recordDeletion(namespace, ResourceImages.String(), from)
+					}
+					if len(failures) > 0 {
+						app.showError(fmt.Sprintf("Created %d of %d references:\n\n%s", created, valid, strings.Join(failures, "\n")))
+					} else {
+						app.updateStatus(fmt.Sprintf("[green]Created %d references[white], deleted %d old ones", created, len(deleted)))
+					}
+					if namespace == app.currentNamespace && app.currentResource == ResourceImages {
+						app.loadItems()
+					}
+				})
+			}()
+		}).
+		AddButton("Cancel", closePreview)
+	buttons.SetCancelFunc(closePreview)
+
+	view.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Retag Preview: %d of %d images (Tab: buttons) ", valid, len(plans))).
+		SetTitleAlign(tview.AlignLeft)
+
+	view.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEscape:
+			closePreview()
+		case tcell.KeyTab:
+			app.tviewApp.SetFocus(buttons)
+		}
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, true).
+		AddItem(buttons, 3, 0, false)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(layout, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("retag-preview", modal, true, true)
+	app.tviewApp.SetFocus(view)
+}
+
+// performRetag creates the new image records, pointing at the same
+// content, and deletes the old ones if asked and the new one was created.
+func (app *App) performRetag(namespace string, plans []retagPlan, deleteOld bool) (int, []string, []string) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+	imageService := app.client.ImageService()
+
+	created := 0
+	var deleted, failures []string
+	for _, plan := range plans {
+		if plan.err != nil {
+			continue
+		}
+
+		img, err := imageService.Get(ctx, plan.from)
+		if err == nil {
+			img.Name = plan.to
+			_, err = imageService.Create(ctx, img)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", plan.to, err))
+			continue
+		}
+		created++
+
+		if deleteOld {
+			// The content stays referenced by the new image
+			if err := imageService.Delete(ctx, plan.from); err != nil && !errdefs.IsNotFound(err) {
+				failures = append(failures, fmt.Sprintf("delete %s: %v", plan.from, err))
+				continue
+			}
+			deleted = append(deleted, plan.from)
+		}
+	}
+
+	return created, deleted, failures
+}
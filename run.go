@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	"github.com/rivo/tview"
+)
+
+// defaultRuntime is the runtime containerd uses when none is requested.
+const defaultRuntime = "io.containerd.runc.v2"
+
+func (app *App) runContainer() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("run")
+		return
+	}
+
+	img, ok := item.(ImageInfo)
+	if !ok {
+		return
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+	runtimes := app.availableRuntimes(ctx)
+	if len(runtimes) == 0 {
+		app.showError("containerd has no task runtime plugin loaded, so it cannot run containers")
+		return
+	}
+
+	var unpacked []string
+	if image, err := app.client.GetImage(ctx, img.Name); err == nil {
+		unpacked = app.unpackedSnapshotters(ctx, image)
+	}
+	snapshotters, labels, selected := app.runSnapshotters(unpacked)
+
+	closeDialog := func() {
+		app.closeDialog("run")
+	}
+
+	form := tview.NewForm().
+		AddInputField("Container ID: ", "", 50, nil, nil).
+		AddDropDown("Runtime:      ", runtimes, max(slices.Index(runtimes, defaultRuntime), 0), nil).
+		AddDropDown("Snapshotter:  ", labels, selected, nil)
+
+	form.AddButton("Run", func() {
+		id := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		_, runtime := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		index, _ := form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+		snapshotter := snapshotters[index]
+		closeDialog()
+
+		if id == "" {
+			app.showError("A container ID is required")
+			return
+		}
+
+		namespace := app.currentNamespace
+		status := fmt.Sprintf("[yellow]Starting:[white] %s from %s (%s)...", id, img.Name, runtime)
+		if !slices.Contains(unpacked, snapshotter) {
+			status = fmt.Sprintf("[yellow]Unpacking for %s and starting:[white] %s from %s (%s)...", snapshotter, id, img.Name, runtime)
+		}
+		app.updateStatus(status)
+
+		// Run the blocking operation in a goroutine to prevent UI freeze
+		go func() {
+			err := app.performRunContainer(namespace, img.Name, id, runtime, snapshotter)
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				if err != nil {
+					app.showError(fmt.Sprintf("Failed to run %s: %v", id, err))
+					return
+				}
+				app.updateStatus(fmt.Sprintf("[green]Started:[white] %s from %s (%s)", id, img.Name, runtime))
+			})
+		}()
+	})
+	form.AddButton("Cancel", closeDialog)
+	form.SetCancelFunc(closeDialog)
+
+	form.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Run %s ", img.Name)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 70, 1, true).
+			AddItem(nil, 0, 1, false), 11, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("run", modal, true, true)
+	app.tviewApp.SetFocus(form)
+}
+
+// Plugin types of the task runtimes. Runtime v2 starts shims, runtime v1
+// is the deprecated io.containerd.runtime.v1.linux.
+const (
+	runtimeV1PluginType = "io.containerd.runtime.v1"
+	runtimeV2PluginType = "io.containerd.runtime.v2"
+)
+
+// availableRuntimes lists the runtimes containers can be created with,
+// according to the task runtime plugins the daemon loaded. Shims are not
+// plugins themselves, so with runtime v2 loaded they are found as
+// containerd-shim-<name>-<version> binaries on PATH, together with the
+// runtimes existing containers already use. Without introspection
+// runtime v2 is assumed, as it is what containerd runs by default.
+func (app *App) availableRuntimes(ctx context.Context) []string {
+	var runtimes []string
+	if ids, ok := app.loadedPlugins(runtimeV1PluginType); ok {
+		for _, id := range ids {
+			runtimes = append(runtimes, runtimeV1PluginType+"."+id)
+		}
+	}
+
+	shims := true
+	if ids, ok := app.loadedPlugins(runtimeV2PluginType); ok {
+		shims = len(ids) > 0
+	}
+
+	if shims {
+		runtimes = append(runtimes, defaultRuntime)
+
+		for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+			matches, _ := filepath.Glob(filepath.Join(dir, "containerd-shim-*-v*"))
+			for _, match := range matches {
+				name, version, ok := cutLast(strings.TrimPrefix(filepath.Base(match), "containerd-shim-"), "-")
+				if ok && name != "" {
+					runtimes = append(runtimes, fmt.Sprintf("io.containerd.%s.%s", name, version))
+				}
+			}
+		}
+	}
+
+	if containerList, err := app.client.ContainerService().List(ctx); err == nil {
+		for _, c := range containerList {
+			if c.Runtime.Name == "" {
+				continue
+			}
+			// Runtime v1 names are all listed already if loaded
+			if shims && !strings.HasPrefix(c.Runtime.Name, runtimeV1PluginType+".") {
+				runtimes = append(runtimes, c.Runtime.Name)
+			}
+		}
+	}
+
+	slices.Sort(runtimes)
+	return slices.Compact(runtimes)
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
+// performRunContainer creates a container from an image with the given
+// runtime and snapshotter, unpacking the image there first if needed, and
+// starts its task detached, with no I/O attached.
+func (app *App) performRunContainer(namespace, imageName, id, runtime, snapshotter string) error {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	image, err := app.client.GetImage(ctx, imageName)
+	if err != nil {
+		return err
+	}
+
+	unpacked, err := image.IsUnpacked(ctx, snapshotter)
+	if err != nil {
+		return err
+	}
+	if !unpacked {
+		if err := image.Unpack(ctx, snapshotter); err != nil {
+			return fmt.Errorf("failed to unpack: %w", err)
+		}
+	}
+
+	container, err := app.client.NewContainer(ctx, id,
+		containerd.WithImage(image),
+		containerd.WithSnapshotter(snapshotter),
+		containerd.WithNewSnapshot(id+"-snapshot", image),
+		containerd.WithNewSpec(oci.WithImageConfig(image)),
+		containerd.WithRuntime(runtime, nil),
+	)
+	if err != nil {
+		return err
+	}
+
+	task, err := container.NewTask(ctx, cio.NullIO)
+	if err != nil {
+		container.Delete(ctx, containerd.WithSnapshotCleanup)
+		return err
+	}
+
+	if err := task.Start(ctx); err != nil {
+		task.Delete(ctx)
+		container.Delete(ctx, containerd.WithSnapshotCleanup)
+		return err
+	}
+
+	return nil
+}
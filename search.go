@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type globalSearchResult struct {
+	resource ResourceType
+	item     interface{}
+}
+
+func (app *App) showGlobalSearch() {
+	input := tview.NewInputField().
+		SetLabel(app.searchLabel("Find everywhere: ")).
+		SetFieldWidth(50)
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		query := strings.TrimSpace(input.GetText())
+		app.closeDialog("global-search")
+
+		if key != tcell.KeyEnter || query == "" {
+			return
+		}
+
+		namespace := app.currentNamespace
+		app.updateStatus(fmt.Sprintf("[yellow]Searching all resources in %s for:[white] %s", namespace, tview.Escape(query)))
+
+		// Run the blocking operation in a goroutine to prevent UI freeze
+		go func() {
+			results, failures := app.performGlobalSearch(namespace, query)
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				app.showGlobalSearchResults(namespace, query, results, failures)
+			})
+		}()
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(input, 70, 1, true).
+			AddItem(nil, 0, 1, false), 3, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("global-search", modal, true, true)
+	app.tviewApp.SetFocus(input)
+}
+
+// performGlobalSearch matches query against every resource type of a
+// namespace, using the same fields as the per-view search.
+func (app *App) performGlobalSearch(namespace, query string) ([]globalSearchResult, []string) {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+
+	var results []globalSearchResult
+	var failures []string
+	for _, resource := range allResources {
+		items, err := app.fetchItems(ctx, resource)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", resource, err))
+			continue
+		}
+
+		for _, item := range items {
+			if app.matchesSearch(searchField(item), query) {
+				results = append(results, globalSearchResult{resource: resource, item: item})
+			}
+		}
+	}
+
+	return results, failures
+}
+
+func (app *App) showGlobalSearchResults(namespace, query string, results []globalSearchResult, failures []string) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+
+	// rows maps table rows to results; group headers map to nil
+	var rows []*globalSearchResult
+	addRow := func(cell *tview.TableCell, result *globalSearchResult) {
+		table.SetCell(len(rows), 0, cell)
+		rows = append(rows, result)
+	}
+
+	for _, resource := range allResources {
+		count := 0
+		for _, result := range results {
+			if result.resource == resource {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+
+		addRow(tview.NewTableCell(fmt.Sprintf("%s (%d)", resource, count)).
+			SetTextColor(tcell.ColorYellow).
+			SetAttributes(tcell.AttrBold).
+			SetSelectable(false), nil)
+
+		for i := range results {
+			if results[i].resource == resource {
+				addRow(tview.NewTableCell("  "+tview.Escape(itemID(results[i].item))).
+					SetTextColor(tcell.ColorWhite), &results[i])
+			}
+		}
+	}
+
+	for _, failure := range failures {
+		addRow(tview.NewTableCell(tview.Escape(failure)).
+			SetTextColor(tcell.ColorRed).
+			SetSelectable(false), nil)
+	}
+
+	if len(results) == 0 {
+		addRow(tview.NewTableCell(fmt.Sprintf("No matches for '%s'", tview.Escape(query))).
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false), nil)
+	}
+
+	closeResults := func() {
+		app.closeDialog("global-results")
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if row < 0 || row >= len(rows) || rows[row] == nil {
+			return
+		}
+		result := *rows[row]
+		closeResults()
+		if namespace == app.currentNamespace {
+			app.jumpToItem(result.resource, result.item)
+		}
+	})
+
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			closeResults()
+		}
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Matches for '%s' in %s: %d (Enter: jump, Esc: close) ", tview.Escape(query), namespace, len(results))).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("global-results", modal, true, true)
+	app.tviewApp.SetFocus(table)
+
+	app.updateStatus(fmt.Sprintf("Found [green]%d[white] matches for '%s'", len(results), tview.Escape(query)))
+}
+
+// jumpToItem switches to the view of a resource type and selects item in
+// it, clearing any search filter that would hide it.
+func (app *App) jumpToItem(resource ResourceType, item interface{}) {
+	if app.currentResource != resource {
+		// Triggers the resource list changed handler, which reloads items
+		app.selectResource(resource)
+	}
+
+	id := itemID(item)
+	if app.searchQuery != "" && !app.selectItem(id) {
+		app.searchQuery = ""
+		app.searchInput.SetText("")
+		if app.contentFiltered {
+			app.loadItems()
+		} else {
+			app.filterItems()
+		}
+	}
+
+	app.selectItem(id)
+	app.tviewApp.SetFocus(app.itemTable)
+}
+
+// selectItem selects the row of the item with the given identifier, on
+// whichever page it is, and reports whether it is currently shown.
+func (app *App) selectItem(id string) bool {
+	for i, item := range app.itemCache {
+		if itemID(item) == id {
+			app.selectIndex(i)
+			return true
+		}
+	}
+	return false
+}
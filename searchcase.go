@@ -0,0 +1,42 @@
+package main
+
+import "strings"
+
+// searchLabel labels the search box with the matching mode.
+func (app *App) searchLabel(label string) string {
+	if app.config.CaseSensitiveSearch {
+		return label + "(Aa) "
+	}
+	return label
+}
+
+// matchesSearch reports whether field contains query, ignoring case unless
+// case-sensitive search is on.
+func (app *App) matchesSearch(field, query string) bool {
+	if app.config.CaseSensitiveSearch {
+		return strings.Contains(field, query)
+	}
+	return strings.Contains(strings.ToLower(field), strings.ToLower(query))
+}
+
+// toggleSearchCase switches between case-insensitive and case-sensitive
+// search, applies it to the current query and remembers it for the next
+// run.
+func (app *App) toggleSearchCase() {
+	app.config.CaseSensitiveSearch = !app.config.CaseSensitiveSearch
+	app.searchInput.SetLabel(app.searchLabel("Search: "))
+
+	app.pageStart = 0
+	if app.contentFiltered {
+		// containerd filtered the walk with the old mode
+		app.loadItems()
+	} else {
+		app.filterItems()
+	}
+
+	state := "ignores case"
+	if app.config.CaseSensitiveSearch {
+		state = "case-sensitive"
+	}
+	app.saveSetting("Search", state)
+}
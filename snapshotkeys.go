@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Keys of the snapshotter's own metadata: "<namespace>/<id>/<key>"
+	namespacedKeyPattern = regexp.MustCompile(`^[^/]+/[0-9]+/(.+)$`)
+
+	// Active snapshots of an unpack in progress, named by the chain ID
+	// they will be committed as: "extract-<nanoseconds>-<random> <chainID>"
+	extractKeyPattern = regexp.MustCompile(`^extract-[0-9]+-\S+ (sha256:[0-9a-f]{64})$`)
+
+	// Chain IDs of committed image layers
+	chainIDPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+	// CRI names the root filesystem snapshots of containers and sandboxes
+	// after their 64 hex digit IDs
+	criIDPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// shortSnapshotKey shortens the snapshot key formats containerd and CRI
+// generate to a readable label, like digests are shortened elsewhere.
+// Other keys are returned unchanged. Only used for display; deletes and
+// copies always use the full key.
+func shortSnapshotKey(key string) string {
+	if m := namespacedKeyPattern.FindStringSubmatch(key); m != nil {
+		key = m[1]
+	}
+
+	switch {
+	case extractKeyPattern.MatchString(key):
+		m := extractKeyPattern.FindStringSubmatch(key)
+		return fmt.Sprintf("unpacking %s", shortChainID(m[1]))
+	case chainIDPattern.MatchString(key):
+		return "layer " + shortChainID(key)
+	case criIDPattern.MatchString(key):
+		return "container " + key[:12]
+	}
+	return key
+}
+
+// shortChainID keeps the first 12 hex digits of a chain ID.
+func shortChainID(id string) string {
+	return strings.TrimPrefix(id, "sha256:")[:12]
+}
+
+// snapshotKeyText returns how a snapshot key is shown in the Snapshots
+// view.
+func (app *App) snapshotKeyText(key string) string {
+	if key == "" || !app.config.ShortSnapshotKeys {
+		return key
+	}
+	return shortSnapshotKey(key)
+}
+
+// toggleShortSnapshotKeys switches the Snapshots view between full keys
+// and shortened labels and remembers the choice for the next run.
+func (app *App) toggleShortSnapshotKeys() {
+	app.config.ShortSnapshotKeys = !app.config.ShortSnapshotKeys
+
+	index := app.selectedIndex()
+	app.renderItemTable()
+	app.selectIndex(index)
+
+	mode := "full"
+	if app.config.ShortSnapshotKeys {
+		mode = "short"
+	}
+	app.saveSetting("Snapshot keys", mode)
+}
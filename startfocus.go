@@ -0,0 +1,26 @@
+package main
+
+import "github.com/rivo/tview"
+
+// Panels the focus can start on.
+const (
+	startFocusNamespaces = ""
+	startFocusResources  = "resources"
+	startFocusItems      = "items"
+)
+
+var startFocusPanels = []string{startFocusNamespaces, startFocusResources, startFocusItems}
+
+// focusStartPanel focuses the panel the config asks to start on; the
+// namespace panel by default.
+func (app *App) focusStartPanel() {
+	var panel tview.Primitive = app.namespaceList
+	switch app.config.StartFocus {
+	case startFocusResources:
+		panel = app.resourceList
+	case startFocusItems:
+		panel = app.itemTable
+	}
+	app.tviewApp.SetFocus(panel)
+	app.panelFocus = panel
+}
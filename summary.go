@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// summaryNouns names the items of each resource type in a namespace
+// summary.
+var summaryNouns = map[ResourceType]string{
+	ResourceImages:     "images",
+	ResourceContainers: "containers",
+	ResourceTasks:      "tasks",
+	ResourceSnapshots:  "snapshots",
+	ResourceContent:    "content blobs",
+}
+
+// copyNamespaceSummary copies a one-line summary of the current namespace
+// to the clipboard, e.g. for a ticket: the host, and the item count of each
+// resource type from the resource panel counts, with the cached content
+// usage and the image sizes the Images view measured.
+func (app *App) copyNamespaceSummary() {
+	namespace := app.currentNamespace
+	if namespace == "" {
+		app.updateStatus("[yellow]No namespace to summarize")
+		return
+	}
+	if app.resourceCounts == nil {
+		// Counting failed or hasn't finished yet; count again for the next try
+		app.countResources()
+		app.updateStatus(fmt.Sprintf("[yellow]Still counting the items of %s, try again in a moment", namespace))
+		return
+	}
+
+	usage := namespaceUsage{namespace: namespace}
+	for _, resource := range allResources {
+		ru := resourceUsage{resource: resource, items: app.resourceCounts[resource]}
+		if resource == ResourceImages {
+			ru.bytes, ru.sized = app.loadedImagesSize(ru.items)
+		}
+		usage.resources = append(usage.resources, ru)
+	}
+
+	app.updateStatus(fmt.Sprintf("[yellow]Summarizing %s...", namespace))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		content, err := app.namespaceContentUsage(namespace)
+		for i := range usage.resources {
+			if ru := &usage.resources[i]; ru.resource == ResourceContent {
+				ru.bytes, ru.sized, ru.err = content.size, true, err
+			}
+		}
+		summary := namespaceSummary(usage)
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.copyToClipboard(summary)
+			app.updateStatus(fmt.Sprintf("[green]Copied:[white] %s", tview.Escape(summary)))
+		})
+	}()
+}
+
+// loadedImagesSize totals the sizes of the images the Images view loaded,
+// if it is the current view, holds all count images and measured them all.
+func (app *App) loadedImagesSize(count int) (total int64, ok bool) {
+	if app.currentResource != ResourceImages || len(app.allItems) != count {
+		return 0, false
+	}
+	for _, item := range app.allItems {
+		img, isImage := item.(ImageInfo)
+		if !isImage || img.Sizing {
+			return 0, false
+		}
+		total += img.Size
+	}
+	return total, count > 0
+}
+
+// namespaceSummary formats a namespace usage as one line, e.g.
+// "k8s.io on node-1: 12 images (1.20 GB), 4 containers, ...". Resource
+// types that failed to load say so instead of a count.
+func namespaceSummary(usage namespaceUsage) string {
+	var parts []string
+	for _, ru := range usage.resources {
+		noun := summaryNouns[ru.resource]
+		switch {
+		case ru.err != nil:
+			parts = append(parts, fmt.Sprintf("%s unavailable", noun))
+		case ru.sized:
+			parts = append(parts, fmt.Sprintf("%d %s (%s)", ru.items, noun, formatSize(ru.bytes)))
+		default:
+			parts = append(parts, fmt.Sprintf("%d %s", ru.items, noun))
+		}
+	}
+
+	where := usage.namespace
+	if host, err := os.Hostname(); err == nil {
+		where += " on " + host
+	}
+	return fmt.Sprintf("%s: %s", where, strings.Join(parts, ", "))
+}
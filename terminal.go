@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// terminalHelp follows the reason lazyctr can't start, pointing to the
+// non-interactive alternative since lazyctr has no output mode of its own.
+const terminalHelp = "lazyctr requires an interactive terminal (%v).\n" +
+	"Run it in a terminal, or over ssh with -t. For non-interactive output, e.g. in CI or a pipe, use ctr instead:\n" +
+	"  ctr -n default images ls\n"
+
+// checkTerminal rejects terminals that can't draw the UI at all, before
+// anything else happens.
+func checkTerminal() error {
+	switch term := os.Getenv("TERM"); term {
+	case "":
+		return fmt.Errorf("TERM is not set")
+	case "dumb":
+		return fmt.Errorf("TERM=dumb has no cursor addressing")
+	}
+	return nil
+}
+
+// newScreen opens the terminal the UI draws on. It fails without a
+// controlling terminal or for terminal types tcell doesn't know.
+func newScreen() (tcell.Screen, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+	return screen, nil
+}
+
+// exitNoTerminal explains why the UI can't start and exits.
+func exitNoTerminal(err error) {
+	fmt.Fprintf(os.Stderr, terminalHelp, err)
+	os.Exit(1)
+}
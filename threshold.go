@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits are the suffixes parseSize accepts, in the binary units
+// formatSize prints.
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// parseSize parses a size such as "512", "100MB" or "1.5 GB". Units are
+// case-insensitive and binary, like the sizes shown in the UI.
+func parseSize(size string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(size))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if number, ok := strings.CutSuffix(text, unit.suffix); ok {
+			text, multiplier = strings.TrimSpace(number), unit.bytes
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+// needsDeleteConfirm reports whether deleting an item of the given size
+// asks first. Without a threshold every delete asks; with one, only items
+// at or above it do, and so do items whose size is unknown.
+func (app *App) needsDeleteConfirm(size int64) bool {
+	if app.deleteThreshold <= 0 {
+		return true
+	}
+	return size <= 0 || size >= app.deleteThreshold
+}
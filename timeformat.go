@@ -0,0 +1,41 @@
+package main
+
+import "time"
+
+// defaultTimeFormat is the layout of timestamp columns unless the config
+// sets another one.
+const defaultTimeFormat = "2006-01-02 15:04"
+
+// validTimeFormat reports whether layout is a usable Go time layout. A
+// layout without any time element prints the same text for two times that
+// differ in every field, and one that cannot parse its own output is
+// malformed.
+func validTimeFormat(layout string) bool {
+	first := time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC)
+	second := time.Date(2023, time.November, 12, 21, 38, 49, 0, time.UTC)
+	text := first.Format(layout)
+	if text == second.Format(layout) {
+		return false
+	}
+	_, err := time.Parse(layout, text)
+	return err == nil
+}
+
+// formatTime renders a timestamp column with the configured layout, in UTC
+// or local time.
+func (app *App) formatTime(t time.Time) string {
+	return formatTimestamp(app.config, t)
+}
+
+func formatTimestamp(config Config, t time.Time) string {
+	layout := config.TimeFormat
+	if layout == "" {
+		layout = defaultTimeFormat
+	}
+	if config.UTC {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	return t.Format(layout)
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cgroupsv1 "github.com/containerd/cgroups/stats/v1"
+	cgroupsv2 "github.com/containerd/cgroups/v2/stats"
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	topInterval = 2 * time.Second
+
+	// clockTicks is USER_HZ, the unit of CPU times in /proc, which is 100
+	// on every Linux architecture.
+	clockTicks = 100
+)
+
+// processSample is one process of a task as seen in /proc.
+type processSample struct {
+	pid     uint32
+	command string
+	ticks   uint64 // user + system CPU time
+	rss     int64
+	cpu     float64 // percent of one CPU since the previous sample
+	known   bool    // false if /proc has nothing for the PID
+}
+
+// taskSample is the usage of a whole task's cgroup, from the task metrics
+// containerd collects through the shim. Unlike /proc, they also cover
+// runtimes that run processes in a VM.
+type taskSample struct {
+	cpuNanos uint64
+	memory   int64
+	cpu      float64 // percent of one CPU since the previous sample
+	known    bool    // false if the runtime reports no cgroup metrics
+}
+
+// showTaskTop shows the processes of the selected task with their CPU and
+// memory use, refreshed every couple of seconds until closed.
+func (app *App) showTaskTop() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("show")
+		return
+	}
+	task, ok := item.(TaskInfo)
+	if !ok {
+		return
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	for i, header := range []string{"PID", "CPU%", "Memory", "Command"} {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	title := fmt.Sprintf(" Top: %s (Esc: close) ", task.ID)
+	table.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft)
+
+	ctx, cancel := context.WithCancel(namespaces.WithNamespace(context.Background(), app.currentNamespace))
+
+	table.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			cancel()
+			app.closeDialog("top")
+		}
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("top", modal, true, true)
+	app.tviewApp.SetFocus(table)
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		ticker := time.NewTicker(topInterval)
+		defer ticker.Stop()
+
+		previous := make(map[uint32]uint64)
+		var previousMetrics taskSample
+		var sampledAt time.Time
+		for {
+			samples, err := app.sampleTaskProcesses(ctx, task.ID)
+			metrics := app.sampleTaskMetrics(ctx, task.ID)
+			now := time.Now()
+			if err == nil {
+				elapsed := now.Sub(sampledAt).Seconds()
+				current := make(map[uint32]uint64, len(samples))
+				for i := range samples {
+					s := &samples[i]
+					current[s.pid] = s.ticks
+					if last, ok := previous[s.pid]; ok && s.known && elapsed > 0 && s.ticks >= last {
+						s.cpu = float64(s.ticks-last) / clockTicks / elapsed * 100
+					}
+				}
+				if metrics.known && previousMetrics.known && elapsed > 0 && metrics.cpuNanos >= previousMetrics.cpuNanos {
+					metrics.cpu = float64(metrics.cpuNanos-previousMetrics.cpuNanos) / float64(time.Second) / elapsed * 100
+				}
+				previous, previousMetrics, sampledAt = current, metrics, now
+			}
+
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					table.SetTitle(fmt.Sprintf(" Top: %s: %v (Esc: close) ", task.ID, err))
+					return
+				}
+				table.SetTitle(topTitle(task.ID, metrics, samples))
+				renderTopTable(table, samples)
+			})
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// sampleTaskProcesses reads the CPU time, memory and command of every
+// process of a task. Process IDs come from containerd and are only found
+// in /proc for runtimes that run processes on the host.
+func (app *App) sampleTaskProcesses(ctx context.Context, id string) ([]processSample, error) {
+	resp, err := app.client.TaskService().ListPids(ctx, &tasks.ListPidsRequest{ContainerID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]processSample, 0, len(resp.Processes))
+	for _, process := range resp.Processes {
+		samples = append(samples, readProcess(process.Pid))
+	}
+	return samples, nil
+}
+
+// sampleTaskMetrics reads the CPU time and memory of a task's cgroup from
+// its metrics, in either cgroup version's format.
+func (app *App) sampleTaskMetrics(ctx context.Context, id string) taskSample {
+	resp, err := app.client.TaskService().Metrics(ctx, &tasks.MetricsRequest{Filters: []string{"id==" + id}})
+	if err != nil || len(resp.Metrics) == 0 || resp.Metrics[0].Data == nil {
+		return taskSample{}
+	}
+
+	data := resp.Metrics[0].Data
+	switch typeURL := data.GetTypeUrl(); {
+	case strings.HasSuffix(typeURL, "io.containerd.cgroups.v1.Metrics"):
+		var metrics cgroupsv1.Metrics
+		if err := metrics.Unmarshal(data.GetValue()); err != nil || metrics.CPU == nil || metrics.CPU.Usage == nil || metrics.Memory == nil || metrics.Memory.Usage == nil {
+			return taskSample{}
+		}
+		return taskSample{cpuNanos: metrics.CPU.Usage.Total, memory: int64(metrics.Memory.Usage.Usage), known: true}
+	case strings.HasSuffix(typeURL, "io.containerd.cgroups.v2.Metrics"):
+		var metrics cgroupsv2.Metrics
+		if err := metrics.Unmarshal(data.GetValue()); err != nil || metrics.CPU == nil || metrics.Memory == nil {
+			return taskSample{}
+		}
+		return taskSample{cpuNanos: metrics.CPU.UsageUsec * uint64(time.Microsecond), memory: int64(metrics.Memory.Usage), known: true}
+	}
+	return taskSample{}
+}
+
+// topTitle sums up a task in the title of its top view: the usage of its
+// cgroup if the runtime reports metrics, else the total of its processes
+// found in /proc.
+func topTitle(id string, metrics taskSample, samples []processSample) string {
+	cpu, memory, source := metrics.cpu, metrics.memory, "cgroup"
+	if !metrics.known {
+		cpu, memory, source = 0, 0, "/proc"
+		known := false
+		for _, s := range samples {
+			if s.known {
+				cpu += s.cpu
+				memory += s.rss
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Sprintf(" Top: %s (Esc: close) ", id)
+		}
+	}
+	return fmt.Sprintf(" Top: %s, CPU %.1f%%, memory %s (%s) (Esc: close) ", id, cpu, formatSize(memory), source)
+}
+
+// readProcess samples a process from /proc/<pid>/stat and status.
+func readProcess(pid uint32) processSample {
+	sample := processSample{pid: pid, command: "-"}
+
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return sample
+	}
+
+	// The command is in parentheses and may itself contain spaces or ")"
+	line := string(stat)
+	open, end := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if open < 0 || end < open {
+		return sample
+	}
+	fields := strings.Fields(line[end+1:])
+	// utime and stime are fields 14 and 15; fields starts at field 3
+	if len(fields) < 13 {
+		return sample
+	}
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+
+	sample.command = line[open+1 : end]
+	sample.ticks = utime + stime
+	sample.known = true
+
+	if cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid)); err == nil && len(cmdline) > 0 {
+		sample.command = strings.TrimSpace(strings.ReplaceAll(string(cmdline), "\x00", " "))
+	}
+
+	if status, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid)); err == nil {
+		for _, line := range strings.Split(string(status), "\n") {
+			if value, ok := strings.CutPrefix(line, "VmRSS:"); ok {
+				kb, _ := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), " kB"), 10, 64)
+				sample.rss = kb * 1024
+				break
+			}
+		}
+	}
+
+	return sample
+}
+
+// renderTopTable shows the busiest processes first, keeping the selected
+// PID selected across refreshes.
+func renderTopTable(table *tview.Table, samples []processSample) {
+	var selectedPID string
+	if row, _ := table.GetSelection(); row > 0 && row < table.GetRowCount() {
+		selectedPID = table.GetCell(row, 0).Text
+	}
+
+	slices.SortFunc(samples, func(a, b processSample) int {
+		if c := cmp.Compare(b.cpu, a.cpu); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.pid, b.pid)
+	})
+
+	for row := table.GetRowCount() - 1; row > 0; row-- {
+		table.RemoveRow(row)
+	}
+
+	for i, s := range samples {
+		cpu, memory := "-", "-"
+		if s.known {
+			cpu = fmt.Sprintf("%.1f", s.cpu)
+			memory = formatSize(s.rss)
+		}
+		pid := strconv.FormatUint(uint64(s.pid), 10)
+
+		table.SetCell(i+1, 0, tview.NewTableCell(pid).SetTextColor(tcell.ColorWhite))
+		table.SetCell(i+1, 1, tview.NewTableCell(cpu).SetTextColor(tcell.ColorGreen).SetAlign(tview.AlignRight))
+		table.SetCell(i+1, 2, tview.NewTableCell(memory).SetTextColor(tcell.ColorTeal).SetAlign(tview.AlignRight))
+		table.SetCell(i+1, 3, tview.NewTableCell(tview.Escape(s.command)).SetTextColor(tcell.ColorWhite).SetExpansion(1))
+
+		if pid == selectedPID {
+			table.Select(i+1, 0)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/pkg/transfer"
+	transferimage "github.com/containerd/containerd/pkg/transfer/image"
+	"github.com/containerd/containerd/pkg/transfer/registry"
+	"github.com/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// transferPluginType is the plugin type of the transfer service, which
+// containerd 1.7 introduced as the successor of client-side pulls.
+const transferPluginType = "io.containerd.transfer.v1"
+
+// detectTransfer checks whether the daemon offers the transfer service.
+// Pulls go through it when it does and fall back to pulling on the client
+// otherwise, e.g. on containerd 1.6 or when introspection is denied.
+func (app *App) detectTransfer() {
+	ids, ok := app.loadedPlugins(transferPluginType)
+	app.transferService = ok && slices.Contains(ids, "local")
+}
+
+// transferCredentials hands the daemon the credentials to pull with when
+// the registry asks for them: creds, or else those stored in the Docker
+// config.
+type transferCredentials struct {
+	app   *App
+	creds *registryCredentials
+}
+
+func (t transferCredentials) GetCredentials(ctx context.Context, ref, host string) (registry.Credentials, error) {
+	if t.creds != nil {
+		return registry.Credentials{Host: host, Username: t.creds.username, Secret: t.creds.secret}, nil
+	}
+	stored := t.app.storedCredentials(host)
+	if stored == nil {
+		return registry.Credentials{}, nil
+	}
+	return registry.Credentials{Host: host, Username: stored.username, Secret: stored.secret}, nil
+}
+
+// performTransferPull pulls an image through the daemon's transfer
+// service, reporting the bytes fetched so far in the status bar.
+func (app *App) performTransferPull(ctx context.Context, ref string, platform ocispec.Platform, unpack bool, creds *registryCredentials) error {
+	opts := []transferimage.StoreOpt{transferimage.WithPlatforms(platform)}
+	if unpack {
+		opts = append(opts, transferimage.WithUnpack(platform, app.snapshotter))
+	}
+
+	source := registry.NewOCIRegistry(ref, nil, transferCredentials{app: app, creds: creds})
+	destination := transferimage.NewStore(ref, opts...)
+
+	// Progress events arrive per blob; keep the latest of each
+	var mu sync.Mutex
+	blobs := make(map[string]transfer.Progress)
+	onProgress := func(p transfer.Progress) {
+		if p.Total == 0 {
+			return
+		}
+		mu.Lock()
+		blobs[p.Name] = p
+		mu.Unlock()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				var fetched, total int64
+				mu.Lock()
+				for _, p := range blobs {
+					fetched += p.Progress
+					total += p.Total
+				}
+				mu.Unlock()
+				if total == 0 {
+					continue
+				}
+				app.tviewApp.QueueUpdateDraw(func() {
+					app.updateStatus(fmt.Sprintf("[yellow]Pulling:[white] %s (%s) %s / %s", ref, platforms.Format(platform), formatSize(fetched), formatSize(total)))
+				})
+			}
+		}
+	}()
+
+	err := app.client.Transfer(ctx, source, destination, transfer.WithProgress(onProgress))
+	close(done)
+	return err
+}
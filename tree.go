@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/distribution/reference"
+)
+
+// treeLayouts define the hierarchical view of the resource types that
+// have a natural one. A layout maps the ID of each nested item to the ID
+// of its parent item; items without a parent are shown at the top level.
+// Adding a grouped view for another resource only needs an entry here.
+var treeLayouts = map[ResourceType]func(items []interface{}) map[string]string{
+	ResourceImages:     imagesByRepository,
+	ResourceContainers: containersByPod,
+	ResourceSnapshots:  snapshotsByParent,
+}
+
+// imagesByRepository nests all tags of a repository under its first one.
+func imagesByRepository(items []interface{}) map[string]string {
+	parents := make(map[string]string)
+	first := make(map[string]string)
+	for _, item := range items {
+		img := item.(ImageInfo)
+		repo := img.Name
+		if named, err := reference.ParseNormalizedNamed(img.Name); err == nil {
+			repo = named.Name()
+		}
+
+		if head, ok := first[repo]; ok {
+			parents[img.Name] = head
+		} else {
+			first[repo] = img.Name
+		}
+	}
+	return parents
+}
+
+// containersByPod nests CRI containers under their pod's sandbox.
+func containersByPod(items []interface{}) map[string]string {
+	parents := make(map[string]string)
+	for _, item := range items {
+		container := item.(ContainerInfo)
+		if container.SandboxID != "" && container.SandboxID != container.ID {
+			parents[container.ID] = container.SandboxID
+		}
+	}
+	return parents
+}
+
+// snapshotsByParent nests snapshots under the snapshot they are based on.
+func snapshotsByParent(items []interface{}) map[string]string {
+	parents := make(map[string]string)
+	for _, item := range items {
+		snapshot := item.(SnapshotInfo)
+		if snapshot.Parent != "" {
+			parents[itemID(snapshot)] = itemID(SnapshotInfo{Key: snapshot.Parent, Snapshotter: snapshot.Snapshotter})
+		}
+	}
+	return parents
+}
+
+// treeOrder arranges items depth first according to parents, keeping the
+// original order among siblings, and returns the tree branches to draw in
+// front of each item. Items whose parent is not shown are top-level.
+func treeOrder(items []interface{}, parents map[string]string) ([]interface{}, []string) {
+	present := make(map[string]bool, len(items))
+	for _, item := range items {
+		present[itemID(item)] = true
+	}
+
+	var roots []interface{}
+	children := make(map[string][]interface{})
+	for _, item := range items {
+		if parent, ok := parents[itemID(item)]; ok && present[parent] {
+			children[parent] = append(children[parent], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	ordered := make([]interface{}, 0, len(items))
+	prefixes := make([]string, 0, len(items))
+	visited := make(map[string]bool, len(items))
+
+	var walk func(item interface{}, branch, indent string)
+	walk = func(item interface{}, branch, indent string) {
+		id := itemID(item)
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+
+		ordered = append(ordered, item)
+		prefixes = append(prefixes, branch)
+
+		kids := children[id]
+		for i, child := range kids {
+			if i == len(kids)-1 {
+				walk(child, indent+"└─ ", indent+"   ")
+			} else {
+				walk(child, indent+"├─ ", indent+"│  ")
+			}
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, "", "")
+	}
+
+	// Parent cycles can't come from containerd, but never drop items
+	for _, item := range items {
+		if !visited[itemID(item)] {
+			ordered = append(ordered, item)
+			prefixes = append(prefixes, "")
+		}
+	}
+
+	return ordered, prefixes
+}
+
+// treeView reports whether the current resource is shown as a tree.
+func (app *App) treeView() bool {
+	return treeLayouts[app.currentResource] != nil && app.config.TreeViews[app.currentResource.String()]
+}
+
+// toggleTreeView switches the current resource between the flat table and
+// its hierarchical view and remembers the choice for the next run.
+func (app *App) toggleTreeView() {
+	if treeLayouts[app.currentResource] == nil {
+		app.updateStatus(fmt.Sprintf("[yellow]%s have no tree view", app.currentResource))
+		return
+	}
+
+	if app.config.TreeViews == nil {
+		app.config.TreeViews = make(map[string]bool)
+	}
+	name := app.currentResource.String()
+	app.config.TreeViews[name] = !app.config.TreeViews[name]
+
+	var selected string
+	if item, ok := app.selectedItem(); ok {
+		selected = itemID(item)
+	}
+	app.filterItems()
+	if selected != "" {
+		app.selectItem(selected)
+	}
+
+	mode := "flat"
+	if app.treeView() {
+		mode = "tree"
+	}
+	app.saveSetting(name+" view", mode)
+}
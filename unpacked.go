@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd"
+)
+
+// unpackedSnapshotters returns the snapshotters an image is unpacked
+// under, out of every available one. Snapshotters that fail to answer are
+// left out, so a broken plugin doesn't hide the others.
+func (app *App) unpackedSnapshotters(ctx context.Context, image containerd.Image) []string {
+	candidates := app.snapshotters
+	if candidates == nil {
+		candidates = []string{app.snapshotter}
+	}
+
+	var unpacked []string
+	for _, name := range candidates {
+		if ok, err := image.IsUnpacked(ctx, name); err == nil && ok {
+			unpacked = append(unpacked, name)
+		}
+	}
+	return unpacked
+}
+
+// unpackedText describes where an image is unpacked for the details view.
+func unpackedText(unpacked []string) string {
+	if len(unpacked) == 0 {
+		return "[yellow]not unpacked[white]"
+	}
+	return strings.Join(unpacked, ", ")
+}
+
+// runSnapshotters returns the snapshotter options of the run dialog, each
+// marked when the image is already unpacked there, and the index of the
+// default: the configured snapshotter if the image is unpacked under it or
+// under none, otherwise the first one it is unpacked under.
+func (app *App) runSnapshotters(unpacked []string) (names, labels []string, selected int) {
+	names = app.snapshotters
+	if !slices.Contains(names, app.snapshotter) {
+		names = append(slices.Clone(names), app.snapshotter)
+		slices.Sort(names)
+	}
+
+	selected = slices.Index(names, app.snapshotter)
+	if len(unpacked) > 0 && !slices.Contains(unpacked, app.snapshotter) {
+		selected = slices.Index(names, unpacked[0])
+	}
+
+	labels = make([]string, len(names))
+	for i, name := range names {
+		labels[i] = name
+		if slices.Contains(unpacked, name) {
+			labels[i] = fmt.Sprintf("%s (unpacked)", name)
+		}
+	}
+	return names, labels, selected
+}
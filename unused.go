@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd/namespaces"
+)
+
+// containerImages returns the names of the images containers of the
+// namespace of ctx were created from.
+func (app *App) containerImages(ctx context.Context) (map[string]bool, error) {
+	containerList, err := app.client.ContainerService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool, len(containerList))
+	for _, c := range containerList {
+		if c.Image != "" {
+			used[c.Image] = true
+		}
+	}
+	return used, nil
+}
+
+// toggleUnusedImages narrows the Images view down to the images no
+// container uses, the ones that are safe to delete.
+func (app *App) toggleUnusedImages() {
+	if !app.unusedOnly {
+		ctx := namespaces.WithNamespace(context.Background(), app.currentNamespace)
+		used, err := app.containerImages(ctx)
+		if err != nil {
+			app.showError(fmt.Sprintf("Failed to list containers: %v", err))
+			return
+		}
+		app.usedImages = used
+	}
+	app.unusedOnly = !app.unusedOnly
+	app.pageStart = 0
+	app.filterItems()
+
+	if app.unusedOnly {
+		app.updateStatus(fmt.Sprintf("Showing [green]%d[white] images no container uses (O: show all)", len(app.itemCache)))
+	}
+}
+
+// unusedImageFilter reports whether the Images view only shows images no
+// container uses.
+func (app *App) unusedImageFilter() bool {
+	return app.unusedOnly && app.currentResource == ResourceImages
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/opencontainers/go-digest"
+	"github.com/rivo/tview"
+)
+
+// contentUsageTTL is how long a namespace's content usage is reused before
+// the content store is walked again. Deletes and pulls invalidate it early.
+const contentUsageTTL = 30 * time.Second
+
+// contentUsage is the aggregate content of one namespace.
+type contentUsage struct {
+	blobs    map[digest.Digest]int64
+	size     int64
+	computed time.Time
+}
+
+// namespaceContentUsage returns the content usage of a namespace, walking
+// the content store only if no fresh cached result exists.
+func (app *App) namespaceContentUsage(namespace string) (contentUsage, error) {
+	app.usageMu.Lock()
+	usage, ok := app.usageCache[namespace]
+	app.usageMu.Unlock()
+	if ok && time.Since(usage.computed) < contentUsageTTL {
+		return usage, nil
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+	usage = contentUsage{blobs: make(map[digest.Digest]int64), computed: time.Now()}
+	err := app.client.ContentStore().Walk(ctx, func(info content.Info) error {
+		usage.blobs[info.Digest] = info.Size
+		usage.size += info.Size
+		return nil
+	})
+	if err != nil {
+		return contentUsage{}, err
+	}
+
+	app.usageMu.Lock()
+	if app.usageCache == nil {
+		app.usageCache = make(map[string]contentUsage)
+	}
+	app.usageCache[namespace] = usage
+	app.usageMu.Unlock()
+
+	return usage, nil
+}
+
+// contentChanged is called after something changed the items of a
+// namespace: it recounts the items if it is the current namespace, and
+// drops its cached content usage and references along with the image
+// verifications that may no longer hold.
+func (app *App) contentChanged(namespace string) {
+	if namespace == app.currentNamespace {
+		app.countResources()
+	}
+
+	app.usageMu.Lock()
+	delete(app.usageCache, namespace)
+	delete(app.refsCache, namespace)
+	app.usageMu.Unlock()
+
+	for key := range app.imageVerifications {
+		if strings.HasPrefix(key, namespace+"@") {
+			delete(app.imageVerifications, key)
+		}
+	}
+}
+
+// resourceUsage is the number of items of one resource type in a
+// namespace and, where items have a size, their total size.
+type resourceUsage struct {
+	resource ResourceType
+	items    int
+	bytes    int64
+	sized    bool
+	err      error
+}
+
+// namespaceUsage breaks down a namespace by resource type. The content
+// usage also keeps the blob digests, to total what is actually on disk.
+type namespaceUsage struct {
+	namespace string
+	resources []resourceUsage
+	content   contentUsage
+}
+
+func (app *App) showDiskUsage() {
+	var nsList []string
+	for i := 0; i < app.namespaceList.GetItemCount(); i++ {
+		name, _ := app.namespaceList.GetItemText(i)
+		nsList = append(nsList, name)
+	}
+	if len(nsList) == 0 {
+		return
+	}
+
+	app.updateStatus("[yellow]Computing disk usage...")
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		usages := make([]namespaceUsage, len(nsList))
+		for i, ns := range nsList {
+			usages[i] = app.computeNamespaceUsage(ns)
+		}
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.showDiskUsageTable(usages)
+		})
+	}()
+}
+
+// computeNamespaceUsage counts the items of every resource type in a
+// namespace with plain List calls, without the per-item lookups the views
+// do. Content comes from the cached content usage, and images are sized
+// by the sizes the Images view measured, once it measured all of them.
+func (app *App) computeNamespaceUsage(namespace string) namespaceUsage {
+	ctx := namespaces.WithNamespace(context.Background(), namespace)
+	usage := namespaceUsage{namespace: namespace}
+
+	for _, resource := range allResources {
+		ru := resourceUsage{resource: resource}
+		switch resource {
+		case ResourceImages:
+			imageList, err := app.client.ImageService().List(ctx)
+			ru.items, ru.err = len(imageList), err
+			ru.bytes, ru.sized = app.cachedImagesSize(namespace, imageList)
+		case ResourceContainers:
+			containerList, err := app.client.ContainerService().List(ctx)
+			ru.items, ru.err = len(containerList), err
+		case ResourceTasks:
+			resp, err := app.client.TaskService().List(ctx, &tasks.ListTasksRequest{})
+			if ru.err = err; err == nil {
+				ru.items = len(resp.Tasks)
+			}
+		case ResourceSnapshots:
+			for _, name := range app.shownSnapshotters() {
+				snapshotList, err := app.walkSnapshots(ctx, name)
+				if err != nil {
+					ru.err = err
+					break
+				}
+				ru.items += len(snapshotList)
+			}
+		case ResourceContent:
+			usage.content, ru.err = app.namespaceContentUsage(namespace)
+			ru.items, ru.bytes, ru.sized = len(usage.content.blobs), usage.content.size, true
+		}
+		usage.resources = append(usage.resources, ru)
+	}
+
+	return usage
+}
+
+func (app *App) showDiskUsageTable(usages []namespaceUsage) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"Namespace"}
+	for _, resource := range allResources {
+		headers = append(headers, resource.String())
+	}
+	for i, header := range headers {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	// Largest content first; blobs shared between namespaces are stored
+	// only once on disk
+	slices.SortStableFunc(usages, func(a, b namespaceUsage) int {
+		return cmp.Compare(b.content.size, a.content.size)
+	})
+
+	unique := make(map[digest.Digest]int64)
+	for row, usage := range usages {
+		table.SetCell(row+1, 0, tview.NewTableCell(usage.namespace).SetTextColor(tcell.ColorWhite))
+		for col, ru := range usage.resources {
+			cell := tview.NewTableCell(fmt.Sprintf("%d", ru.items)).SetTextColor(tcell.ColorTeal)
+			switch {
+			case ru.err != nil:
+				cell.SetText("error").SetTextColor(tcell.ColorRed)
+			case ru.sized:
+				cell.SetText(fmt.Sprintf("%d (%s)", ru.items, app.sizeText(ru.bytes))).SetTextColor(tcell.ColorGreen)
+			}
+			table.SetCell(row+1, col+1, cell)
+		}
+		for dgst, size := range usage.content.blobs {
+			unique[dgst] = size
+		}
+	}
+
+	var total int64
+	for _, size := range unique {
+		total += size
+	}
+	row := len(usages) + 1
+	table.SetCell(row, 0, tview.NewTableCell("On disk").SetTextColor(tcell.ColorYellow).SetSelectable(false))
+	table.SetCell(row, len(headers)-1, tview.NewTableCell(fmt.Sprintf("%d (%s)", len(unique), app.sizeText(total))).
+		SetTextColor(tcell.ColorGreen).
+		SetSelectable(false))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			app.closeDialog("usage")
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == 'w':
+			app.exportDiskUsage(usages, table)
+			return nil
+		}
+		return event
+	})
+
+	table.SetBorder(true).
+		SetTitle(" Disk Usage by Namespace (w: export CSV, Esc: close) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(table, 0, 6, true).
+			AddItem(nil, 0, 1, false), min(len(usages)+4, 20), 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("usage", modal, true, true)
+	app.tviewApp.SetFocus(table)
+
+	app.updateStatus(fmt.Sprintf("Content on disk: [green]%s[white] in %d blobs", formatSize(total), len(unique)))
+}
+
+// exportDiskUsage prompts for a path and writes the usage breakdown to it
+// as CSV, one row per namespace and resource type.
+func (app *App) exportDiskUsage(usages []namespaceUsage, table *tview.Table) {
+	pathInput := tview.NewInputField().
+		SetLabel("Save to: ").
+		SetFieldWidth(60).
+		SetText(fmt.Sprintf("lazyctr-usage-%s.csv", time.Now().Format("20060102-150405")))
+
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		path := strings.TrimSpace(pathInput.GetText())
+		app.pages.RemovePage("usage-export")
+		app.tviewApp.SetFocus(table)
+
+		if key != tcell.KeyEnter || path == "" {
+			return
+		}
+
+		if err := writeUsageCSV(path, usages); err != nil {
+			app.showError(fmt.Sprintf("Failed to export disk usage: %v", err))
+			return
+		}
+		app.updateStatus(fmt.Sprintf("[green]Exported disk usage:[white] %s", path))
+	})
+
+	form := tview.NewForm().
+		AddFormItem(pathInput)
+
+	form.SetBorder(true).
+		SetTitle(" Export Disk Usage ").
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false), 5, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("usage-export", modal, true, true)
+	app.tviewApp.SetFocus(pathInput)
+}
+
+// writeUsageCSV writes the usage breakdown with exact byte counts. Sizes
+// of resources without one (containers, tasks, snapshots) are left empty.
+// Existing files are never overwritten.
+func writeUsageCSV(path string, usages []namespaceUsage) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"namespace", "resource_type", "item_count", "total_bytes"})
+	for _, usage := range usages {
+		for _, ru := range usage.resources {
+			if ru.err != nil {
+				continue
+			}
+			bytes := ""
+			if ru.sized {
+				bytes = strconv.FormatInt(ru.bytes, 10)
+			}
+			w.Write([]string{usage.namespace, ru.resource.String(), strconv.Itoa(ru.items), bytes})
+		}
+	}
+	w.Flush()
+
+	err = w.Error()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/platforms"
+	"github.com/gdamore/tcell/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rivo/tview"
+)
+
+// imageVerification is the result of checking that the content of an
+// image is all in the content store.
+type imageVerification struct {
+	checked int
+	missing []ocispec.Descriptor
+	// err is set when the manifest itself could not be read, e.g. because
+	// it is missing too
+	err error
+}
+
+func (v imageVerification) complete() bool {
+	return v.err == nil && len(v.missing) == 0
+}
+
+// verificationKey identifies the content of an image in a namespace, so
+// tags sharing it share the result.
+func verificationKey(namespace string, img ImageInfo) string {
+	return namespace + "@" + img.Target.Digest.String()
+}
+
+// verifyImages checks the marked images, or the selected one, for content
+// missing from the content store, e.g. after a partial pull or a garbage
+// collection that removed blobs still referenced.
+func (app *App) verifyImages() {
+	var targets []ImageInfo
+	for _, item := range app.markedItems() {
+		if img, ok := item.(ImageInfo); ok {
+			targets = append(targets, img)
+		}
+	}
+	if len(targets) == 0 {
+		item, ok := app.selectedItem()
+		if !ok {
+			app.reportNothingSelected("verify")
+			return
+		}
+		targets = append(targets, item.(ImageInfo))
+	}
+
+	namespace := app.currentNamespace
+	app.updateStatus(fmt.Sprintf("[yellow]Verifying %d images...", len(targets)))
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		ctx := namespaces.WithNamespace(context.Background(), namespace)
+		results := make([]imageVerification, len(targets))
+		for i, img := range targets {
+			results[i] = app.verifyImage(ctx, img)
+		}
+
+		// Queue UI updates on the main thread
+		app.tviewApp.QueueUpdateDraw(func() {
+			for i, img := range targets {
+				app.imageVerifications[verificationKey(namespace, img)] = results[i]
+			}
+			if namespace == app.currentNamespace && app.currentResource == ResourceImages {
+				index := app.selectedIndex()
+				app.renderItemTable()
+				app.selectIndex(index)
+			}
+			app.showVerifyResults(targets, results)
+		})
+	}()
+}
+
+// verifyImage checks that the manifest, config and layers of an image are
+// in the content store. The manifest is the one for this host, or any for
+// images of a foreign platform.
+func (app *App) verifyImage(ctx context.Context, img ImageInfo) imageVerification {
+	contentStore := app.client.ContentStore()
+
+	var matcher platforms.MatchComparer = platforms.Default()
+	if img.Foreign {
+		matcher = nil
+	}
+	manifest, err := images.Manifest(ctx, contentStore, img.Target, matcher)
+	if err != nil {
+		return imageVerification{err: err}
+	}
+
+	var result imageVerification
+	for _, desc := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+		result.checked++
+		if _, err := contentStore.Info(ctx, desc.Digest); errdefs.IsNotFound(err) {
+			result.missing = append(result.missing, desc)
+		} else if err != nil {
+			return imageVerification{err: err}
+		}
+	}
+	return result
+}
+
+func (app *App) showVerifyResults(targets []ImageInfo, results []imageVerification) {
+	var b strings.Builder
+	incomplete := 0
+	for i, img := range targets {
+		result := results[i]
+		switch {
+		case result.err != nil:
+			incomplete++
+			fmt.Fprintf(&b, "[red]✗ %s[white]\n  %s\n", tview.Escape(img.Name), tview.Escape(result.err.Error()))
+		case len(result.missing) > 0:
+			incomplete++
+			fmt.Fprintf(&b, "[red]✗ %s[white]: %d of %d blobs missing\n", tview.Escape(img.Name), len(result.missing), result.checked)
+			for _, desc := range result.missing {
+				kind := "layer"
+				if images.IsConfigType(desc.MediaType) {
+					kind = "config"
+				}
+				fmt.Fprintf(&b, "  %-6s %s (%s)\n", kind, desc.Digest, formatSize(desc.Size))
+			}
+		default:
+			fmt.Fprintf(&b, "[green]✓ %s[white]: all %d blobs present\n", tview.Escape(img.Name), result.checked)
+		}
+	}
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(b.String())
+
+	textView.SetDoneFunc(func(key tcell.Key) {
+		app.closeDialog("verify")
+	})
+
+	textView.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Verified %d images: %d incomplete (Esc: close) ", len(targets), incomplete)).
+		SetTitleAlign(tview.AlignLeft)
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(textView, 0, 6, true).
+			AddItem(nil, 0, 1, false), 0, 6, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("verify", modal, true, true)
+	app.tviewApp.SetFocus(textView)
+
+	if incomplete > 0 {
+		app.updateStatus(fmt.Sprintf("[red]%d of %d images are incomplete", incomplete, len(targets)))
+	} else {
+		app.updateStatus(fmt.Sprintf("[green]All %d images are complete", len(targets)))
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	watchInterval = time.Second
+
+	// watchHistory is how many status changes the watch view keeps.
+	watchHistory = 10
+)
+
+// watchSample is the state of the watched item at one poll.
+type watchSample struct {
+	status string
+	pid    uint32
+	gone   bool
+}
+
+// watchChange is a status change seen while watching.
+type watchChange struct {
+	at       time.Time
+	from, to string
+}
+
+// watchItem opens a small view polling the status of the selected
+// container or task every second, without reloading the whole table.
+func (app *App) watchItem() {
+	item, ok := app.selectedItem()
+	if !ok {
+		app.reportNothingSelected("watch")
+		return
+	}
+
+	var id string
+	var poll func(ctx context.Context) (watchSample, error)
+	switch v := item.(type) {
+	case ContainerInfo:
+		id = v.ID
+		poll = func(ctx context.Context) (watchSample, error) { return app.pollContainer(ctx, v.ID) }
+	case TaskInfo:
+		id = v.ID
+		poll = func(ctx context.Context) (watchSample, error) { return app.pollTask(ctx, v.ID, v.Orphaned) }
+	default:
+		return
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Watch %s: %s (Esc: close) ", app.currentResource, id)).
+		SetTitleAlign(tview.AlignLeft)
+
+	ctx, cancel := context.WithCancel(namespaces.WithNamespace(context.Background(), app.currentNamespace))
+
+	view.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			cancel()
+			app.closeDialog("watch")
+		}
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(view, 70, 1, true).
+			AddItem(nil, 0, 1, false), 18, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("watch", modal, true, true)
+	app.tviewApp.SetFocus(view)
+
+	// Run the blocking operation in a goroutine to prevent UI freeze
+	go func() {
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+
+		var last *watchSample
+		var since time.Time
+		var changes []watchChange
+		for {
+			sample, err := poll(ctx)
+			now := time.Now()
+			if err == nil {
+				switch {
+				case last == nil:
+					since = now
+				case sample.status != last.status:
+					changes = append(changes, watchChange{at: now, from: last.status, to: sample.status})
+					if len(changes) > watchHistory {
+						changes = changes[1:]
+					}
+					since = now
+				}
+				last = &sample
+			}
+			text := watchText(last, since, changes, err, now)
+
+			// Queue UI updates on the main thread
+			app.tviewApp.QueueUpdateDraw(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				view.SetText(text)
+			})
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// pollContainer returns the status of a container, which is the status
+// of its task or "Stopped" without one.
+func (app *App) pollContainer(ctx context.Context, id string) (watchSample, error) {
+	container, err := app.client.LoadContainer(ctx, id)
+	if errdefs.IsNotFound(err) {
+		return watchSample{status: "deleted", gone: true}, nil
+	}
+	if err != nil {
+		return watchSample{}, err
+	}
+
+	info, err := app.containerInfo(ctx, container)
+	if err != nil {
+		return watchSample{}, err
+	}
+	sample := watchSample{status: info.Status}
+	if task, err := container.Task(ctx, nil); err == nil {
+		sample.pid = task.Pid()
+	}
+	return sample, nil
+}
+
+// pollTask returns the status of a task. Orphaned tasks have no container
+// to load them through and are asked for directly.
+func (app *App) pollTask(ctx context.Context, id string, orphaned bool) (watchSample, error) {
+	if orphaned {
+		resp, err := app.client.TaskService().Get(ctx, &tasks.GetRequest{ContainerID: id})
+		if errdefs.IsNotFound(err) {
+			return watchSample{status: "deleted", gone: true}, nil
+		}
+		if err != nil {
+			return watchSample{}, err
+		}
+		return watchSample{status: strings.ToLower(resp.Process.Status.String()), pid: resp.Process.Pid}, nil
+	}
+
+	container, err := app.client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return watchSample{status: "deleted", gone: true}, nil
+		}
+		return watchSample{}, err
+	}
+
+	info, err := app.taskInfo(ctx, container)
+	if errdefs.IsNotFound(err) {
+		return watchSample{status: "deleted", gone: true}, nil
+	}
+	if err != nil {
+		return watchSample{}, err
+	}
+	return watchSample{status: info.Status, pid: info.PID}, nil
+}
+
+// watchText renders the watch view: the current status, how long it has
+// held, and the latest status changes, newest first.
+func watchText(sample *watchSample, since time.Time, changes []watchChange, err error, now time.Time) string {
+	var b strings.Builder
+	if sample == nil {
+		fmt.Fprintf(&b, "[red]%s[white]\n", tview.Escape(err.Error()))
+		return b.String()
+	}
+
+	label, color := taskStatusStyle(sample.status)
+	if sample.gone {
+		label, color = "deleted", tcell.ColorRed
+	}
+	fmt.Fprintf(&b, "Status: [%s]%s[white]\n", color.Name(), label)
+	if sample.pid > 0 && !sample.gone {
+		fmt.Fprintf(&b, "PID:    %d\n", sample.pid)
+	}
+	fmt.Fprintf(&b, "Since:  %s (%s)\n", since.Format("15:04:05"), now.Sub(since).Round(time.Second))
+	if err != nil {
+		fmt.Fprintf(&b, "\n[red]Last poll failed: %s[white]\n", tview.Escape(err.Error()))
+	}
+
+	b.WriteString("\nChanges:\n")
+	if len(changes) == 0 {
+		b.WriteString("[gray]none yet[white]\n")
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		fmt.Fprintf(&b, "%s  %s → %s\n", change.at.Format("15:04:05"), change.from, change.to)
+	}
+	return b.String()
+}